@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    starlark = {
+      source = "ms-henglu/starlark"
+    }
+  }
+}
+
+provider "starlark" {}
+
+locals {
+  network = provider::starlark::exports(
+    <<-EOT
+    _prefix = "10.0.%d.0/24"
+
+    def subnet(i):
+      return _prefix % i
+
+    subnets = [subnet(i) for i in range(int(count))]
+    routes  = {"default": gateway}
+    EOT
+    ,
+    {
+      count   = 2
+      gateway = "10.0.0.1"
+    }
+  )
+}
+
+output "subnets" {
+  value = local.network.subnets
+}
+# Output: ["10.0.0.0/24", "10.0.1.0/24"]
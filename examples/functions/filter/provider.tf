@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    starlark = {
+      source = "ms-henglu/starlark"
+    }
+  }
+}
+
+provider "starlark" {}
+
+output "even" {
+  value = provider::starlark::filter([1, 2, 3, 4], "lambda x: x % 2 == 0")
+}
+# Output: [2, 4]
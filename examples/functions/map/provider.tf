@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    starlark = {
+      source = "ms-henglu/starlark"
+    }
+  }
+}
+
+provider "starlark" {}
+
+output "doubled" {
+  value = provider::starlark::map([1, 2, 3], "lambda x: x * 2")
+}
+# Output: [2, 4, 6]
@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    starlark = {
+      source = "ms-henglu/starlark"
+    }
+  }
+}
+
+provider "starlark" {}
+
+output "total" {
+  value = provider::starlark::reduce([1, 2, 3, 4], "lambda acc, x: acc + x", 0)
+}
+# Output: 10
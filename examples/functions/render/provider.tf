@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    starlark = {
+      source = "ms-henglu/starlark"
+    }
+  }
+}
+
+provider "starlark" {}
+
+output "greeting" {
+  value = provider::starlark::render(
+    "Hello, {{ name | html }}! You have {{ len(items) }} items.",
+    {
+      name  = "O'Brien <admin>"
+      items = ["a", "b"]
+    }
+  )
+}
+# Output: "Hello, O&#39;Brien &lt;admin&gt;! You have 2 items."
+
+output "upstream" {
+  value = provider::starlark::render(
+    <<-EOT
+    upstream {{ name }} {
+    {%- for s in servers %}
+      server {{ s["host"] }}{% if s["backup"] %} backup{% endif %};
+    {%- endfor %}
+    }
+    EOT
+    ,
+    {
+      name = "app"
+      servers = [
+        { host = "10.0.0.1:8080", backup = false },
+        { host = "10.0.0.2:8080", backup = true },
+      ]
+    }
+  )
+}
@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    starlark = {
+      source = "ms-henglu/starlark"
+    }
+  }
+}
+
+provider "starlark" {}
+
+output "by_name" {
+  value = provider::starlark::sort_by(
+    [{ name = "web" }, { name = "api" }, { name = "db" }],
+    "lambda x: x.name"
+  )
+}
+# Output: [{ name = "api" }, { name = "db" }, { name = "web" }]
@@ -0,0 +1,671 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// cloudidModule implements the "cloudid" module for Azure resource IDs, AWS ARNs and GCP self-links.
+var cloudidModule = &starlarkstruct.Module{
+	Name: "cloudid",
+	Members: starlark.StringDict{
+		"azure_parse":  starlark.NewBuiltin("cloudid.azure_parse", cloudidAzureParse),
+		"azure_format": starlark.NewBuiltin("cloudid.azure_format", cloudidAzureFormat),
+		"azure_parent": starlark.NewBuiltin("cloudid.azure_parent", cloudidAzureParent),
+		"azure_equal":  starlark.NewBuiltin("cloudid.azure_equal", cloudidAzureEqual),
+		"arn_parse":    starlark.NewBuiltin("cloudid.arn_parse", cloudidArnParse),
+		"arn_format":   starlark.NewBuiltin("cloudid.arn_format", cloudidArnFormat),
+		"arn_equal":    starlark.NewBuiltin("cloudid.arn_equal", cloudidArnEqual),
+		"gcp_parse":    starlark.NewBuiltin("cloudid.gcp_parse", cloudidGcpParse),
+		"gcp_format":   starlark.NewBuiltin("cloudid.gcp_format", cloudidGcpFormat),
+		"gcp_parent":   starlark.NewBuiltin("cloudid.gcp_parent", cloudidGcpParent),
+		"gcp_equal":    starlark.NewBuiltin("cloudid.gcp_equal", cloudidGcpEqual),
+	},
+}
+
+// typedName is one type/name pair of a resource path, such as virtualNetworks/vnet1.
+type typedName struct {
+	typ, name string
+}
+
+func typedNamesToList(pairs []typedName) *starlark.List {
+	values := make([]starlark.Value, len(pairs))
+	for i, p := range pairs {
+		d := starlark.NewDict(2)
+		_ = d.SetKey(starlark.String("type"), starlark.String(p.typ))
+		_ = d.SetKey(starlark.String("name"), starlark.String(p.name))
+		values[i] = d
+	}
+	return starlark.NewList(values)
+}
+
+func typedNamesFromValue(v starlark.Value) ([]typedName, error) {
+	if v == nil || v == starlark.None {
+		return nil, nil
+	}
+	values, err := iterableValues(v)
+	if err != nil {
+		return nil, fmt.Errorf("resources: %s", err)
+	}
+	pairs := make([]typedName, len(values))
+	for i, value := range values {
+		m, ok := value.(starlark.Mapping)
+		if !ok {
+			return nil, fmt.Errorf("resources[%d]: got %s, want dict", i, value.Type())
+		}
+		for _, key := range []string{"type", "name"} {
+			s, err := idField(m, key)
+			if err != nil {
+				return nil, fmt.Errorf("resources[%d]: %s", i, err)
+			}
+			if s == "" {
+				return nil, fmt.Errorf("resources[%d]: missing %q", i, key)
+			}
+			if key == "type" {
+				pairs[i].typ = s
+			} else {
+				pairs[i].name = s
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// idFieldTarget is a string field of a parts dict and where to store it.
+type idFieldTarget struct {
+	key string
+	dst *string
+}
+
+// idField returns the string field key of m, or "" if it is missing or None.
+func idField(m starlark.Mapping, key string) (string, error) {
+	v, found, err := m.Get(starlark.String(key))
+	if err != nil {
+		return "", err
+	}
+	if !found || v == starlark.None {
+		return "", nil
+	}
+	s, ok := starlark.AsString(v)
+	if !ok {
+		return "", fmt.Errorf("%q must be a string, got %s", key, v.Type())
+	}
+	return s, nil
+}
+
+func optionalString(s string) starlark.Value {
+	if s == "" {
+		return starlark.None
+	}
+	return starlark.String(s)
+}
+
+func unpackIDParts(b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Mapping, error) {
+	var parts starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "parts", &parts); err != nil {
+		return nil, err
+	}
+	m, ok := parts.(starlark.Mapping)
+	if !ok {
+		return nil, fmt.Errorf("%s: got %s, want dict", b.Name(), parts.Type())
+	}
+	return m, nil
+}
+
+// azureID is a parsed Azure resource ID. An extension resource, such as a role assignment on a
+// virtual machine, has the ID of the resource it extends as its scope.
+type azureID struct {
+	subscription  string
+	resourceGroup string
+	scope         string
+	provider      string
+	resources     []typedName
+}
+
+func parseAzureID(id string) (*azureID, error) {
+	trimmed := strings.TrimSuffix(id, "/")
+	if !strings.HasPrefix(trimmed, "/") || trimmed == "" {
+		return nil, fmt.Errorf("%q is not an Azure resource ID: must start with /", id)
+	}
+	segments := strings.Split(trimmed[1:], "/")
+	for _, s := range segments {
+		if s == "" {
+			return nil, fmt.Errorf("%q is not an Azure resource ID: empty segment", id)
+		}
+	}
+
+	result := &azureID{}
+	offset := 1 // byte offset of segments[i] in trimmed, used to cut the scope of extension resources
+	for i := 0; i < len(segments); {
+		key := segments[i]
+		switch {
+		case strings.EqualFold(key, "providers"):
+			if i+1 >= len(segments) {
+				return nil, fmt.Errorf("%q is not an Azure resource ID: missing provider namespace", id)
+			}
+			if result.provider != "" {
+				result.scope = trimmed[:offset-1]
+			}
+			result.provider = segments[i+1]
+			result.resources = nil
+			offset += len(key) + len(segments[i+1]) + 2
+			i += 2
+			continue
+		case result.provider == "" && strings.EqualFold(key, "subscriptions") && i == 0:
+			if i+1 >= len(segments) {
+				return nil, fmt.Errorf("%q is not an Azure resource ID: missing subscription ID", id)
+			}
+			result.subscription = segments[i+1]
+		case result.provider == "" && strings.EqualFold(key, "resourceGroups") && result.subscription != "" && result.resourceGroup == "":
+			if i+1 >= len(segments) {
+				return nil, fmt.Errorf("%q is not an Azure resource ID: missing resource group name", id)
+			}
+			result.resourceGroup = segments[i+1]
+		case result.provider != "":
+			if i+1 >= len(segments) {
+				return nil, fmt.Errorf("%q is not an Azure resource ID: missing name for type %q", id, key)
+			}
+			result.resources = append(result.resources, typedName{typ: key, name: segments[i+1]})
+		default:
+			return nil, fmt.Errorf("%q is not an Azure resource ID: unexpected segment %q", id, key)
+		}
+		offset += len(key) + len(segments[i+1]) + 2
+		i += 2
+	}
+	if result.provider != "" && len(result.resources) == 0 {
+		return nil, fmt.Errorf("%q is not an Azure resource ID: missing resource type after provider %q", id, result.provider)
+	}
+	return result, nil
+}
+
+func (a *azureID) resourceType() string {
+	if a.provider == "" {
+		if a.resourceGroup != "" {
+			return "Microsoft.Resources/resourceGroups"
+		}
+		return "Microsoft.Resources/subscriptions"
+	}
+	types := []string{a.provider}
+	for _, r := range a.resources {
+		types = append(types, r.typ)
+	}
+	return strings.Join(types, "/")
+}
+
+func (a *azureID) name() string {
+	switch {
+	case len(a.resources) > 0:
+		return a.resources[len(a.resources)-1].name
+	case a.resourceGroup != "":
+		return a.resourceGroup
+	default:
+		return a.subscription
+	}
+}
+
+func (a *azureID) String() string {
+	var sb strings.Builder
+	if a.scope != "" {
+		sb.WriteString(a.scope)
+	} else if a.subscription != "" {
+		sb.WriteString("/subscriptions/" + a.subscription)
+		if a.resourceGroup != "" {
+			sb.WriteString("/resourceGroups/" + a.resourceGroup)
+		}
+	}
+	if a.provider != "" {
+		sb.WriteString("/providers/" + a.provider)
+		for _, r := range a.resources {
+			sb.WriteString("/" + r.typ + "/" + r.name)
+		}
+	}
+	return sb.String()
+}
+
+// parent returns the ID of the resource or scope that contains a, or "" for a subscription or a
+// tenant-level resource.
+func (a *azureID) parent() string {
+	switch {
+	case len(a.resources) > 1:
+		p := *a
+		p.resources = a.resources[:len(a.resources)-1]
+		return p.String()
+	case len(a.resources) == 1 && a.scope != "":
+		return a.scope
+	case len(a.resources) == 1:
+		p := azureID{subscription: a.subscription, resourceGroup: a.resourceGroup}
+		return p.String()
+	case a.resourceGroup != "":
+		return "/subscriptions/" + a.subscription
+	default:
+		return ""
+	}
+}
+
+// cloudidAzureParse returns the parts of an Azure resource, resource group or subscription ID.
+func cloudidAzureParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "id", &id); err != nil {
+		return nil, err
+	}
+	a, err := parseAzureID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	d := starlark.NewDict(8)
+	_ = d.SetKey(starlark.String("subscription_id"), optionalString(a.subscription))
+	_ = d.SetKey(starlark.String("resource_group"), optionalString(a.resourceGroup))
+	_ = d.SetKey(starlark.String("scope"), optionalString(a.scope))
+	_ = d.SetKey(starlark.String("provider"), optionalString(a.provider))
+	_ = d.SetKey(starlark.String("resources"), typedNamesToList(a.resources))
+	_ = d.SetKey(starlark.String("resource_type"), starlark.String(a.resourceType()))
+	_ = d.SetKey(starlark.String("name"), optionalString(a.name()))
+	_ = d.SetKey(starlark.String("parent"), optionalString(a.parent()))
+	return d, nil
+}
+
+// cloudidAzureFormat builds an Azure resource ID from the fields returned by azure_parse. When
+// "scope" is set it replaces "subscription_id" and "resource_group".
+func cloudidAzureFormat(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	m, err := unpackIDParts(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	var a azureID
+	for _, f := range []idFieldTarget{
+		{"subscription_id", &a.subscription},
+		{"resource_group", &a.resourceGroup},
+		{"scope", &a.scope},
+		{"provider", &a.provider},
+	} {
+		if *f.dst, err = idField(m, f.key); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	resources, _, err := m.Get(starlark.String("resources"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if a.resources, err = typedNamesFromValue(resources); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	switch {
+	case a.resourceGroup != "" && a.subscription == "" && a.scope == "":
+		return nil, fmt.Errorf("%s: resource_group requires subscription_id", b.Name())
+	case a.provider != "" && len(a.resources) == 0:
+		return nil, fmt.Errorf("%s: provider requires at least one entry in resources", b.Name())
+	case a.provider == "" && len(a.resources) > 0:
+		return nil, fmt.Errorf("%s: resources require a provider", b.Name())
+	case a.provider == "" && a.scope != "":
+		return nil, fmt.Errorf("%s: scope requires a provider", b.Name())
+	case a.scope != "" && !strings.HasPrefix(a.scope, "/"):
+		return nil, fmt.Errorf("%s: scope must start with /", b.Name())
+	}
+	id := a.String()
+	if id == "" {
+		return nil, fmt.Errorf("%s: need subscription_id, scope or provider", b.Name())
+	}
+	return starlark.String(id), nil
+}
+
+func cloudidAzureParent(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "id", &id); err != nil {
+		return nil, err
+	}
+	a, err := parseAzureID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return optionalString(a.parent()), nil
+}
+
+// cloudidAzureEqual compares two Azure resource IDs. Azure Resource Manager treats IDs as
+// case-insensitive, so "resourcegroups/RG1" and "resourceGroups/rg1" are the same.
+func cloudidAzureEqual(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &x, "b", &y); err != nil {
+		return nil, err
+	}
+	return starlark.Bool(strings.EqualFold(strings.TrimSuffix(x, "/"), strings.TrimSuffix(y, "/"))), nil
+}
+
+// arn is a parsed Amazon Resource Name: arn:partition:service:region:account-id:resource.
+type arn struct {
+	partition, service, region, account, resource string
+}
+
+func parseARN(s string) (*arn, error) {
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return nil, fmt.Errorf("%q is not an ARN: want arn:partition:service:region:account-id:resource", s)
+	}
+	a := &arn{partition: parts[1], service: parts[2], region: parts[3], account: parts[4], resource: parts[5]}
+	switch {
+	case a.partition == "":
+		return nil, fmt.Errorf("%q is not an ARN: missing partition", s)
+	case a.service == "":
+		return nil, fmt.Errorf("%q is not an ARN: missing service", s)
+	case a.resource == "":
+		return nil, fmt.Errorf("%q is not an ARN: missing resource", s)
+	}
+	return a, nil
+}
+
+func (a *arn) String() string {
+	return strings.Join([]string{"arn", a.partition, a.service, a.region, a.account, a.resource}, ":")
+}
+
+// resourceTypeAndID splits the resource at its first "/" or ":", as in "instance/i-123" or
+// "function:my-function:prod". Resources without a separator have no type.
+func (a *arn) resourceTypeAndID() (string, string) {
+	if i := strings.IndexAny(a.resource, "/:"); i >= 0 {
+		return a.resource[:i], a.resource[i+1:]
+	}
+	return "", a.resource
+}
+
+func cloudidArnParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "arn", &s); err != nil {
+		return nil, err
+	}
+	a, err := parseARN(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	typ, id := a.resourceTypeAndID()
+	d := starlark.NewDict(7)
+	_ = d.SetKey(starlark.String("partition"), starlark.String(a.partition))
+	_ = d.SetKey(starlark.String("service"), starlark.String(a.service))
+	_ = d.SetKey(starlark.String("region"), starlark.String(a.region))
+	_ = d.SetKey(starlark.String("account_id"), starlark.String(a.account))
+	_ = d.SetKey(starlark.String("resource"), starlark.String(a.resource))
+	_ = d.SetKey(starlark.String("resource_type"), optionalString(typ))
+	_ = d.SetKey(starlark.String("resource_id"), starlark.String(id))
+	return d, nil
+}
+
+// cloudidArnFormat builds an ARN from the fields returned by arn_parse. Without "resource", the
+// resource is "resource_type/resource_id", or just "resource_id".
+func cloudidArnFormat(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	m, err := unpackIDParts(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	var a arn
+	var typ, id string
+	for _, f := range []idFieldTarget{
+		{"partition", &a.partition},
+		{"service", &a.service},
+		{"region", &a.region},
+		{"account_id", &a.account},
+		{"resource", &a.resource},
+		{"resource_type", &typ},
+		{"resource_id", &id},
+	} {
+		if *f.dst, err = idField(m, f.key); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	if a.resource == "" {
+		a.resource = id
+		if typ != "" {
+			a.resource = typ + "/" + id
+		}
+	}
+	switch {
+	case a.partition == "":
+		return nil, fmt.Errorf("%s: missing partition", b.Name())
+	case a.service == "":
+		return nil, fmt.Errorf("%s: missing service", b.Name())
+	case id == "" && a.resource == "":
+		return nil, fmt.Errorf("%s: missing resource", b.Name())
+	}
+	return starlark.String(a.String()), nil
+}
+
+// cloudidArnEqual compares two ARNs. The partition, service and region are compared
+// case-insensitively; the account and resource must match exactly, since AWS matches them
+// case-sensitively in policies.
+func cloudidArnEqual(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &x, "b", &y); err != nil {
+		return nil, err
+	}
+	ax, err := parseARN(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	ay, err := parseARN(y)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.Bool(strings.EqualFold(ax.partition, ay.partition) &&
+		strings.EqualFold(ax.service, ay.service) &&
+		strings.EqualFold(ax.region, ay.region) &&
+		ax.account == ay.account &&
+		ax.resource == ay.resource), nil
+}
+
+// gcpID is a parsed Google Cloud resource name. base is whatever precedes "projects/", such as
+// "https://www.googleapis.com/compute/v1/" in a self-link or "//compute.googleapis.com/" in a full
+// resource name.
+type gcpID struct {
+	base         string
+	service      string
+	version      string
+	project      string
+	locationType string // "zones", "regions", "locations" or "global"
+	location     string
+	resources    []typedName
+}
+
+var gcpVersionPattern = regexp.MustCompile(`^v\d+\w*$`)
+
+func parseGCPID(s string) (*gcpID, error) {
+	i := strings.Index(s, "projects/")
+	if i < 0 || (i > 0 && s[i-1] != '/') {
+		return nil, fmt.Errorf("%q is not a GCP resource name: missing projects/", s)
+	}
+	g := &gcpID{base: s[:i]}
+
+	if g.base != "" {
+		rest, ok := strings.CutPrefix(g.base, "https:")
+		if !ok {
+			rest, ok = strings.CutPrefix(g.base, "http:")
+		}
+		if rest, ok = strings.CutPrefix(rest, "//"); !ok {
+			return nil, fmt.Errorf("%q is not a GCP resource name: unexpected prefix %q", s, g.base)
+		}
+		path := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+		host := path[0]
+		path = path[1:]
+		if host == "www.googleapis.com" && len(path) > 0 {
+			g.service, path = path[0], path[1:]
+		} else {
+			g.service = strings.TrimSuffix(host, ".googleapis.com")
+		}
+		if len(path) > 0 && gcpVersionPattern.MatchString(path[0]) {
+			g.version = path[0]
+		}
+	}
+
+	segments := strings.Split(strings.TrimSuffix(s[i:], "/"), "/")
+	if len(segments) < 2 || segments[1] == "" {
+		return nil, fmt.Errorf("%q is not a GCP resource name: missing project", s)
+	}
+	g.project = segments[1]
+	segments = segments[2:]
+	if len(segments) > 0 && segments[0] == "global" {
+		g.locationType = "global"
+		segments = segments[1:]
+	} else if len(segments) > 1 && (segments[0] == "zones" || segments[0] == "regions" || segments[0] == "locations") {
+		g.locationType, g.location = segments[0], segments[1]
+		segments = segments[2:]
+	}
+	if len(segments)%2 != 0 {
+		return nil, fmt.Errorf("%q is not a GCP resource name: missing name for type %q", s, segments[len(segments)-1])
+	}
+	for j := 0; j < len(segments); j += 2 {
+		if segments[j] == "" || segments[j+1] == "" {
+			return nil, fmt.Errorf("%q is not a GCP resource name: empty segment", s)
+		}
+		g.resources = append(g.resources, typedName{typ: segments[j], name: segments[j+1]})
+	}
+	return g, nil
+}
+
+// relativeName returns the resource name without the base, such as
+// "projects/p/zones/us-central1-a/instances/vm".
+func (g *gcpID) relativeName() string {
+	var sb strings.Builder
+	sb.WriteString("projects/" + g.project)
+	switch {
+	case g.locationType == "global":
+		sb.WriteString("/global")
+	case g.locationType != "":
+		sb.WriteString("/" + g.locationType + "/" + g.location)
+	}
+	for _, r := range g.resources {
+		sb.WriteString("/" + r.typ + "/" + r.name)
+	}
+	return sb.String()
+}
+
+func (g *gcpID) String() string {
+	return g.base + g.relativeName()
+}
+
+// parent returns the name of the resource that contains g, or "" for a project.
+func (g *gcpID) parent() string {
+	p := *g
+	switch {
+	case len(g.resources) > 0:
+		p.resources = g.resources[:len(g.resources)-1]
+	case g.locationType != "":
+		p.locationType, p.location = "", ""
+	default:
+		return ""
+	}
+	return p.String()
+}
+
+func cloudidGcpParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &s); err != nil {
+		return nil, err
+	}
+	g, err := parseGCPID(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	resourceType, name := "projects", g.project
+	if len(g.resources) > 0 {
+		last := g.resources[len(g.resources)-1]
+		resourceType, name = last.typ, last.name
+	}
+	location := g.location
+	if g.locationType == "global" {
+		location = "global"
+	}
+	d := starlark.NewDict(11)
+	_ = d.SetKey(starlark.String("base"), starlark.String(g.base))
+	_ = d.SetKey(starlark.String("service"), optionalString(g.service))
+	_ = d.SetKey(starlark.String("version"), optionalString(g.version))
+	_ = d.SetKey(starlark.String("project"), starlark.String(g.project))
+	_ = d.SetKey(starlark.String("location_type"), optionalString(g.locationType))
+	_ = d.SetKey(starlark.String("location"), optionalString(location))
+	_ = d.SetKey(starlark.String("resources"), typedNamesToList(g.resources))
+	_ = d.SetKey(starlark.String("resource_type"), starlark.String(resourceType))
+	_ = d.SetKey(starlark.String("name"), starlark.String(name))
+	_ = d.SetKey(starlark.String("relative_name"), starlark.String(g.relativeName()))
+	_ = d.SetKey(starlark.String("parent"), optionalString(g.parent()))
+	return d, nil
+}
+
+// cloudidGcpFormat builds a GCP resource name from the fields returned by gcp_parse. "base" is
+// prepended as is, so an empty base gives a relative name.
+func cloudidGcpFormat(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	m, err := unpackIDParts(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	var g gcpID
+	for _, f := range []idFieldTarget{
+		{"base", &g.base},
+		{"project", &g.project},
+		{"location_type", &g.locationType},
+		{"location", &g.location},
+	} {
+		if *f.dst, err = idField(m, f.key); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	resources, _, err := m.Get(starlark.String("resources"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if g.resources, err = typedNamesFromValue(resources); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	if g.project == "" {
+		return nil, fmt.Errorf("%s: missing project", b.Name())
+	}
+	if g.base != "" && !strings.HasSuffix(g.base, "/") {
+		g.base += "/"
+	}
+	switch g.locationType {
+	case "":
+		if g.location != "" {
+			return nil, fmt.Errorf("%s: location requires location_type", b.Name())
+		}
+	case "global":
+	case "zones", "regions", "locations":
+		if g.location == "" {
+			return nil, fmt.Errorf("%s: location_type %q requires location", b.Name(), g.locationType)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unknown location_type %q, want zones, regions, locations or global", b.Name(), g.locationType)
+	}
+	return starlark.String(g.String()), nil
+}
+
+func cloudidGcpParent(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &s); err != nil {
+		return nil, err
+	}
+	g, err := parseGCPID(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return optionalString(g.parent()), nil
+}
+
+// cloudidGcpEqual compares two GCP resource names by their relative names, so a self-link and a
+// relative name of the same resource are equal. GCP names are case-sensitive.
+func cloudidGcpEqual(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &x, "b", &y); err != nil {
+		return nil, err
+	}
+	gx, err := parseGCPID(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	gy, err := parseGCPID(y)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.Bool(gx.relativeName() == gy.relativeName()), nil
+}
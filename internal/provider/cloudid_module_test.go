@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCloudidModule_azure(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval("result = cloudid.azure_parse(id)", {
+						id = "/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/snet1"
+					})
+				}
+				output "extension" {
+					value = provider::starlark::eval("result = cloudid.azure_parse(id)", {
+						id = "/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/vm1/providers/Microsoft.Authorization/roleAssignments/ra1"
+					})
+				}
+				output "format" {
+					value = provider::starlark::eval(
+						<<-EOT
+						parts = cloudid.azure_parse(id)
+						parts["resources"][-1]["name"] = "snet2"
+						result = cloudid.azure_format(parts)
+						EOT
+						,
+						{ id = "/subscriptions/0000/resourcegroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/snet1" }
+					)
+				}
+				output "parents" {
+					value = provider::starlark::eval("result = [cloudid.azure_parent(id) for id in ids]", {
+						ids = ["/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Web/sites/app", "/subscriptions/0000/resourceGroups/rg1", "/subscriptions/0000"]
+					})
+				}
+				output "equal" {
+					value = provider::starlark::eval("result = cloudid.azure_equal('/subscriptions/0000/resourcegroups/RG1/', '/subscriptions/0000/resourceGroups/rg1')", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"subscription_id": "0000",
+						"resource_group":  "rg1",
+						"scope":           nil,
+						"provider":        "Microsoft.Network",
+						"resources": []interface{}{
+							map[string]interface{}{"type": "virtualNetworks", "name": "vnet1"},
+							map[string]interface{}{"type": "subnets", "name": "snet1"},
+						},
+						"resource_type": "Microsoft.Network/virtualNetworks/subnets",
+						"name":          "snet1",
+						"parent":        "/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1",
+					}),
+					NewTestCheckOutput("extension", map[string]interface{}{
+						"subscription_id": "0000",
+						"resource_group":  "rg1",
+						"scope":           "/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/vm1",
+						"provider":        "Microsoft.Authorization",
+						"resources": []interface{}{
+							map[string]interface{}{"type": "roleAssignments", "name": "ra1"},
+						},
+						"resource_type": "Microsoft.Authorization/roleAssignments",
+						"name":          "ra1",
+						"parent":        "/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/vm1",
+					}),
+					resource.TestCheckOutput("format", "/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/snet2"),
+					NewTestCheckOutput("parents", []interface{}{"/subscriptions/0000/resourceGroups/rg1", "/subscriptions/0000", nil}),
+					resource.TestCheckOutput("equal", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudidModule_arn(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval("result = cloudid.arn_parse('arn:aws:lambda:eu-west-1:123456789012:function:my-func:prod')", {})
+				}
+				output "format" {
+					value = provider::starlark::eval(
+						"result = cloudid.arn_format({'partition': 'aws', 'service': 'iam', 'account_id': '123456789012', 'resource_type': 'role', 'resource_id': 'admin'})",
+						{}
+					)
+				}
+				output "equal" {
+					value = provider::starlark::eval("result = [cloudid.arn_equal('arn:aws:iam::1:role/x', 'arn:aws:iam::1:role/x'), cloudid.arn_equal('arn:aws:iam::1:role/x', 'arn:aws:iam::1:role/X')]", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"partition":     "aws",
+						"service":       "lambda",
+						"region":        "eu-west-1",
+						"account_id":    "123456789012",
+						"resource":      "function:my-func:prod",
+						"resource_type": "function",
+						"resource_id":   "my-func:prod",
+					}),
+					resource.TestCheckOutput("format", "arn:aws:iam::123456789012:role/admin"),
+					NewTestCheckOutput("equal", []interface{}{true, false}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudidModule_gcp(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval("result = cloudid.gcp_parse(link)", {
+						link = "https://www.googleapis.com/compute/v1/projects/p1/zones/us-central1-a/instances/vm1"
+					})
+				}
+				output "format" {
+					value = provider::starlark::eval(
+						"result = cloudid.gcp_format({'project': 'p1', 'location_type': 'global', 'resources': [{'type': 'networks', 'name': 'default'}]})",
+						{}
+					)
+				}
+				output "equal" {
+					value = provider::starlark::eval(
+						"result = cloudid.gcp_equal('https://www.googleapis.com/compute/v1/projects/p1/global/networks/default', 'projects/p1/global/networks/default')",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"base":          "https://www.googleapis.com/compute/v1/",
+						"service":       "compute",
+						"version":       "v1",
+						"project":       "p1",
+						"location_type": "zones",
+						"location":      "us-central1-a",
+						"resources": []interface{}{
+							map[string]interface{}{"type": "instances", "name": "vm1"},
+						},
+						"resource_type": "instances",
+						"name":          "vm1",
+						"relative_name": "projects/p1/zones/us-central1-a/instances/vm1",
+						"parent":        "https://www.googleapis.com/compute/v1/projects/p1/zones/us-central1-a",
+					}),
+					resource.TestCheckOutput("format", "projects/p1/global/networks/default"),
+					resource.TestCheckOutput("equal", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudidModule_invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = cloudid.azure_parse('/subscriptions/0000/resourceGroups/rg1/providers/Microsoft.Web/sites')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`missing name for type`),
+			},
+		},
+	})
+}
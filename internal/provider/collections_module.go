@@ -0,0 +1,830 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// collectionsModule implements the "collections" module for nested dicts and lists.
+//
+// Functions never modify their arguments: anything that changes a value returns a deep copy.
+var collectionsModule = &starlarkstruct.Module{
+	Name: "collections",
+	Members: starlark.StringDict{
+		"deep_merge":   starlark.NewBuiltin("collections.deep_merge", collectionsDeepMerge),
+		"get":          starlark.NewBuiltin("collections.get", collectionsGet),
+		"set":          starlark.NewBuiltin("collections.set", collectionsSet),
+		"delete":       starlark.NewBuiltin("collections.delete", collectionsDelete),
+		"flatten":      starlark.NewBuiltin("collections.flatten", collectionsFlatten),
+		"unflatten":    starlark.NewBuiltin("collections.unflatten", collectionsUnflatten),
+		"walk":         starlark.NewBuiltin("collections.walk", collectionsWalk),
+		"pick":         starlark.NewBuiltin("collections.pick", collectionsPick),
+		"omit":         starlark.NewBuiltin("collections.omit", collectionsOmit),
+		"natural_sort": starlark.NewBuiltin("collections.natural_sort", collectionsNaturalSort),
+	},
+}
+
+// deepCopy copies dicts and lists recursively. Tuples are copied as lists; other values are immutable and shared.
+// It fails if a dict or list contains itself.
+func deepCopy(v starlark.Value) (starlark.Value, error) {
+	return deepCopyPath(v, nil)
+}
+
+// deepCopyPath copies v, where path holds the dicts and lists that contain it.
+func deepCopyPath(v starlark.Value, path []starlark.Value) (starlark.Value, error) {
+	switch v := v.(type) {
+	case *starlark.Dict:
+		if err := checkCycle(v, path); err != nil {
+			return nil, err
+		}
+		out := starlark.NewDict(v.Len())
+		for _, item := range v.Items() {
+			conv, err := deepCopyPath(item[1], append(path, v))
+			if err != nil {
+				return nil, err
+			}
+			_ = out.SetKey(item[0], conv)
+		}
+		return out, nil
+	case *starlark.List:
+		if err := checkCycle(v, path); err != nil {
+			return nil, err
+		}
+		return copyList(v, append(path, v))
+	case starlark.Tuple:
+		return copyList(v, path)
+	default:
+		return v, nil
+	}
+}
+
+func copyList(v starlark.Indexable, path []starlark.Value) (starlark.Value, error) {
+	elems := make([]starlark.Value, v.Len())
+	for i := range elems {
+		conv, err := deepCopyPath(v.Index(i), path)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = conv
+	}
+	return starlark.NewList(elems), nil
+}
+
+// checkCycle fails if v, a dict or list, is already on path, the containers being visited.
+func checkCycle(v starlark.Value, path []starlark.Value) error {
+	for _, seen := range path {
+		if seen == v {
+			return fmt.Errorf("cycle: %s contains itself", v.Type())
+		}
+	}
+	return nil
+}
+
+// deep_merge
+
+type mergeOptions struct {
+	lists string
+	key   string
+}
+
+// collectionsDeepMerge merges dicts from left to right. Nested dicts are merged recursively, and any
+// other value from a later dict replaces the earlier one, except lists, which follow the list strategy.
+func collectionsDeepMerge(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	opts := mergeOptions{lists: "replace"}
+	if err := starlark.UnpackArgs(b.Name(), nil, kwargs, "lists?", &opts.lists, "key?", &opts.key); err != nil {
+		return nil, err
+	}
+	switch opts.lists {
+	case "replace", "append":
+	case "merge_by_key":
+		if opts.key == "" {
+			return nil, fmt.Errorf(`%s: lists = "merge_by_key" requires key`, b.Name())
+		}
+	default:
+		return nil, fmt.Errorf(`%s: lists must be "replace", "append" or "merge_by_key", got %q`, b.Name(), opts.lists)
+	}
+
+	result := starlark.NewDict(0)
+	for i, arg := range args {
+		d, ok := arg.(*starlark.Dict)
+		if !ok {
+			return nil, fmt.Errorf("%s: argument %d: got %s, want dict", b.Name(), i+1, arg.Type())
+		}
+		if err := mergeDicts(result, d, opts); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	return result, nil
+}
+
+// mergeDicts merges src into dst, which must be a copy owned by the caller.
+func mergeDicts(dst, src *starlark.Dict, opts mergeOptions) error {
+	for _, item := range src.Items() {
+		k, v := item[0], item[1]
+		existing, found, err := dst.Get(k)
+		if err != nil {
+			return err
+		}
+		merged, err := mergeValues(existing, found, v, opts)
+		if err != nil {
+			return fmt.Errorf("key %s: %s", k, err)
+		}
+		if err := dst.SetKey(k, merged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeValues(existing starlark.Value, found bool, v starlark.Value, opts mergeOptions) (starlark.Value, error) {
+	if !found {
+		return deepCopy(v)
+	}
+	if dst, ok := existing.(*starlark.Dict); ok {
+		if src, ok := v.(*starlark.Dict); ok {
+			if err := mergeDicts(dst, src, opts); err != nil {
+				return nil, err
+			}
+			return dst, nil
+		}
+	}
+	dst, ok := existing.(*starlark.List)
+	src, isList := v.(starlark.Indexable)
+	if _, isString := v.(starlark.String); !ok || !isList || isString || opts.lists == "replace" {
+		return deepCopy(v)
+	}
+
+	if opts.lists == "append" {
+		for i := 0; i < src.Len(); i++ {
+			elem, err := deepCopy(src.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if err := dst.Append(elem); err != nil {
+				return nil, err
+			}
+		}
+		return dst, nil
+	}
+
+	// merge_by_key: dicts with the same key value are merged, everything else is appended.
+	key := starlark.String(opts.key)
+	for i := 0; i < src.Len(); i++ {
+		elem := src.Index(i)
+		srcDict, ok := elem.(*starlark.Dict)
+		var id starlark.Value
+		if ok {
+			id, ok, _ = srcDict.Get(key)
+		}
+		matched := false
+		for j := 0; ok && j < dst.Len(); j++ {
+			dstDict, isDict := dst.Index(j).(*starlark.Dict)
+			if !isDict {
+				continue
+			}
+			other, found, _ := dstDict.Get(key)
+			if !found {
+				continue
+			}
+			eq, err := starlark.Equal(id, other)
+			if err != nil {
+				return nil, err
+			}
+			if eq {
+				if err := mergeDicts(dstDict, srcDict, opts); err != nil {
+					return nil, err
+				}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			conv, err := deepCopy(elem)
+			if err != nil {
+				return nil, err
+			}
+			if err := dst.Append(conv); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dst, nil
+}
+
+// Paths
+
+// pathSegment is one step of a path: a dict key, or a list index when isIndex is set.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// parsePath parses a path such as `a.b[2].c` or `tags["kubernetes.io/name"]`.
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	s := path
+	expectKey := true
+	for s != "" {
+		switch {
+		case s[0] == '[':
+			var end int
+			if len(s) > 1 && (s[1] == '"' || s[1] == '\'') {
+				// A quoted key may contain "." and "]", so look for the closing quote first.
+				closing := strings.IndexByte(s[2:], s[1])
+				if closing < 0 || 2+closing+1 >= len(s) || s[2+closing+1] != ']' {
+					return nil, fmt.Errorf("invalid path %q: bad quoted key", path)
+				}
+				segments = append(segments, pathSegment{key: s[2 : 2+closing]})
+				end = 2 + closing + 1
+			} else {
+				end = strings.IndexByte(s, ']')
+				if end < 0 {
+					return nil, fmt.Errorf("invalid path %q: unterminated [", path)
+				}
+				i, err := strconv.Atoi(strings.TrimSpace(s[1:end]))
+				if err != nil {
+					return nil, fmt.Errorf("invalid path %q: %q is not an index", path, s[1:end])
+				}
+				segments = append(segments, pathSegment{index: i, isIndex: true})
+			}
+			s = s[end+1:]
+			expectKey = false
+		case s[0] == '.':
+			if expectKey {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			s = s[1:]
+			expectKey = true
+			if s == "" {
+				return nil, fmt.Errorf("invalid path %q: trailing .", path)
+			}
+		default:
+			if !expectKey {
+				return nil, fmt.Errorf("invalid path %q: expected . or [", path)
+			}
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			segments = append(segments, pathSegment{key: s[:end]})
+			s = s[end:]
+			expectKey = false
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+	return segments, nil
+}
+
+func formatPath(segments []pathSegment) string {
+	var buf strings.Builder
+	for i, seg := range segments {
+		switch {
+		case seg.isIndex:
+			fmt.Fprintf(&buf, "[%d]", seg.index)
+		case identifierPattern.MatchString(seg.key):
+			if i > 0 {
+				buf.WriteByte('.')
+			}
+			buf.WriteString(seg.key)
+		default:
+			fmt.Fprintf(&buf, "[%s]", strconv.Quote(seg.key))
+		}
+	}
+	return buf.String()
+}
+
+// child returns the element of v selected by seg.
+func (seg pathSegment) child(v starlark.Value) (starlark.Value, bool) {
+	if seg.isIndex {
+		l, ok := v.(starlark.Indexable)
+		if !ok {
+			return nil, false
+		}
+		if _, isString := v.(starlark.String); isString {
+			return nil, false
+		}
+		i := seg.index
+		if i < 0 {
+			i += l.Len()
+		}
+		if i < 0 || i >= l.Len() {
+			return nil, false
+		}
+		return l.Index(i), true
+	}
+	m, ok := v.(starlark.Mapping)
+	if !ok {
+		return nil, false
+	}
+	child, found, err := m.Get(starlark.String(seg.key))
+	if err != nil || !found {
+		return nil, false
+	}
+	return child, true
+}
+
+func lookupPath(v starlark.Value, segments []pathSegment) (starlark.Value, bool) {
+	for _, seg := range segments {
+		var ok bool
+		if v, ok = seg.child(v); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// setPath sets the value at segments within v, which must be a copy owned by the caller.
+// Missing dict keys along the way are created as dicts.
+func setPath(v starlark.Value, segments []pathSegment, value starlark.Value) error {
+	seg := segments[0]
+	last := len(segments) == 1
+	if seg.isIndex {
+		l, ok := v.(*starlark.List)
+		if !ok {
+			return fmt.Errorf("cannot index %s", v.Type())
+		}
+		i := seg.index
+		if i < 0 {
+			i += l.Len()
+		}
+		if i < 0 || i >= l.Len() {
+			return fmt.Errorf("index %d out of range for list of length %d", seg.index, l.Len())
+		}
+		if last {
+			return l.SetIndex(i, value)
+		}
+		return setPath(l.Index(i), segments[1:], value)
+	}
+
+	d, ok := v.(*starlark.Dict)
+	if !ok {
+		return fmt.Errorf("cannot set key %q on %s", seg.key, v.Type())
+	}
+	key := starlark.String(seg.key)
+	if last {
+		return d.SetKey(key, value)
+	}
+	next, found, err := d.Get(key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		next = starlark.NewDict(0)
+		if err := d.SetKey(key, next); err != nil {
+			return err
+		}
+	}
+	return setPath(next, segments[1:], value)
+}
+
+// deletePath removes the value at segments within v, which must be a copy owned by the caller.
+// Missing paths are ignored.
+func deletePath(v starlark.Value, segments []pathSegment) error {
+	parent, ok := lookupPath(v, segments[:len(segments)-1])
+	if !ok {
+		return nil
+	}
+	seg := segments[len(segments)-1]
+	if seg.isIndex {
+		l, ok := parent.(*starlark.List)
+		if !ok {
+			return nil
+		}
+		i := seg.index
+		if i < 0 {
+			i += l.Len()
+		}
+		if i < 0 || i >= l.Len() {
+			return nil
+		}
+		elems := make([]starlark.Value, 0, l.Len()-1)
+		for j := 0; j < l.Len(); j++ {
+			if j != i {
+				elems = append(elems, l.Index(j))
+			}
+		}
+		if err := l.Clear(); err != nil {
+			return err
+		}
+		for _, e := range elems {
+			if err := l.Append(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if d, ok := parent.(*starlark.Dict); ok {
+		_, _, err := d.Delete(starlark.String(seg.key))
+		return err
+	}
+	return nil
+}
+
+func collectionsGet(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	var path string
+	var dflt starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "path", &path, "default?", &dflt); err != nil {
+		return nil, err
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if v, ok := lookupPath(value, segments); ok {
+		return v, nil
+	}
+	return dflt, nil
+}
+
+func collectionsSet(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value, v starlark.Value
+	var path string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "path", &path, "v", &v); err != nil {
+		return nil, err
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	result, err := deepCopy(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if v, err = deepCopy(v); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if err := setPath(result, segments, v); err != nil {
+		return nil, fmt.Errorf("%s: %s: %s", b.Name(), path, err)
+	}
+	return result, nil
+}
+
+func collectionsDelete(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	var path string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "path", &path); err != nil {
+		return nil, err
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	result, err := deepCopy(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if err := deletePath(result, segments); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return result, nil
+}
+
+// pick and omit
+
+func unpackPaths(paths *starlark.List) ([][]pathSegment, error) {
+	out := make([][]pathSegment, 0, paths.Len())
+	for i := 0; i < paths.Len(); i++ {
+		s, ok := starlark.AsString(paths.Index(i))
+		if !ok {
+			return nil, fmt.Errorf("paths must be strings, got %s", paths.Index(i).Type())
+		}
+		segments, err := parsePath(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, segments)
+	}
+	return out, nil
+}
+
+// collectionsPick returns a dict with only the given paths. Paths that do not exist are skipped.
+func collectionsPick(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	var paths *starlark.List
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "paths", &paths); err != nil {
+		return nil, err
+	}
+	all, err := unpackPaths(paths)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	result := starlark.NewDict(0)
+	for _, segments := range all {
+		v, ok := lookupPath(value, segments)
+		if !ok {
+			continue
+		}
+		for _, seg := range segments {
+			if seg.isIndex {
+				return nil, fmt.Errorf("%s: %s: list indexes are not supported", b.Name(), formatPath(segments))
+			}
+		}
+		if v, err = deepCopy(v); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+		if err := setPath(result, segments, v); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	return result, nil
+}
+
+// collectionsOmit returns a copy of the dict without the given paths.
+func collectionsOmit(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	var paths *starlark.List
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "paths", &paths); err != nil {
+		return nil, err
+	}
+	all, err := unpackPaths(paths)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	result, err := deepCopy(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	for _, segments := range all {
+		if err := deletePath(result, segments); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	return result, nil
+}
+
+// flatten and unflatten
+
+// collectionsFlatten turns nested dicts into a single dict whose keys are joined with sep.
+// Lists are kept as values unless lists is True, in which case their indexes become key segments.
+func collectionsFlatten(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	sep := "."
+	lists := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "sep?", &sep, "lists?", &lists); err != nil {
+		return nil, err
+	}
+	result := starlark.NewDict(0)
+	// path holds the dicts and lists that contain v.
+	var visit func(prefix string, v starlark.Value, path []starlark.Value) error
+	visit = func(prefix string, v starlark.Value, path []starlark.Value) error {
+		switch x := v.(type) {
+		case *starlark.Dict:
+			if x.Len() > 0 {
+				if err := checkCycle(x, path); err != nil {
+					return err
+				}
+				for _, item := range x.Items() {
+					k, ok := starlark.AsString(item[0])
+					if !ok {
+						return fmt.Errorf("keys must be strings, got %s", item[0].Type())
+					}
+					if err := visit(prefix+sep+k, item[1], append(path, x)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		case *starlark.List:
+			if lists && x.Len() > 0 {
+				if err := checkCycle(x, path); err != nil {
+					return err
+				}
+				for i := 0; i < x.Len(); i++ {
+					if err := visit(prefix+sep+strconv.Itoa(i), x.Index(i), append(path, x)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+		// Leaves, and empty dicts and lists, are kept as values.
+		leaf, err := deepCopyPath(v, path)
+		if err != nil {
+			return err
+		}
+		return result.SetKey(starlark.String(prefix), leaf)
+	}
+	for _, item := range value.Items() {
+		k, ok := starlark.AsString(item[0])
+		if !ok {
+			return nil, fmt.Errorf("%s: keys must be strings, got %s", b.Name(), item[0].Type())
+		}
+		if err := visit(k, item[1], []starlark.Value{value}); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+	return result, nil
+}
+
+// collectionsUnflatten is the inverse of flatten for dicts: keys are split on sep into nested dicts.
+func collectionsUnflatten(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	sep := "."
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "sep?", &sep); err != nil {
+		return nil, err
+	}
+	if sep == "" {
+		return nil, fmt.Errorf("%s: sep must not be empty", b.Name())
+	}
+
+	// Process keys in sorted order so conflicts are reported deterministically.
+	items, err := sortedStringItems(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	result := starlark.NewDict(0)
+	for _, item := range items {
+		parts := strings.Split(item.key, sep)
+		d := result
+		for i, part := range parts {
+			key := starlark.String(part)
+			existing, found, err := d.Get(key)
+			if err != nil {
+				return nil, err
+			}
+			if i == len(parts)-1 {
+				if found {
+					return nil, fmt.Errorf("%s: key %q conflicts with a nested key", b.Name(), item.key)
+				}
+				v, err := deepCopy(item.value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s", b.Name(), err)
+				}
+				if err := d.SetKey(key, v); err != nil {
+					return nil, err
+				}
+				break
+			}
+			if !found {
+				next := starlark.NewDict(0)
+				if err := d.SetKey(key, next); err != nil {
+					return nil, err
+				}
+				d = next
+				continue
+			}
+			next, ok := existing.(*starlark.Dict)
+			if !ok {
+				return nil, fmt.Errorf("%s: key %q conflicts with key %q", b.Name(), item.key, strings.Join(parts[:i+1], sep))
+			}
+			d = next
+		}
+	}
+	return result, nil
+}
+
+// walk
+
+// collectionsWalk calls fn(path, value) for every leaf, that is every value that is not a dict or
+// list, and returns a copy of value with each leaf replaced by the result.
+func collectionsWalk(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	var fn starlark.Callable
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "fn", &fn); err != nil {
+		return nil, err
+	}
+
+	// parents holds the dicts and lists that contain v.
+	var visit func(path []pathSegment, v starlark.Value, parents []starlark.Value) (starlark.Value, error)
+	visit = func(path []pathSegment, v starlark.Value, parents []starlark.Value) (starlark.Value, error) {
+		switch x := v.(type) {
+		case *starlark.Dict:
+			if err := checkCycle(x, parents); err != nil {
+				return nil, err
+			}
+			out := starlark.NewDict(x.Len())
+			for _, item := range x.Items() {
+				k, ok := starlark.AsString(item[0])
+				if !ok {
+					return nil, fmt.Errorf("keys must be strings, got %s", item[0].Type())
+				}
+				conv, err := visit(append(path[:len(path):len(path)], pathSegment{key: k}), item[1], append(parents, x))
+				if err != nil {
+					return nil, err
+				}
+				if err := out.SetKey(item[0], conv); err != nil {
+					return nil, err
+				}
+			}
+			return out, nil
+		case *starlark.List, starlark.Tuple:
+			// Tuples cannot contain themselves except through a list.
+			if list, ok := x.(*starlark.List); ok {
+				if err := checkCycle(list, parents); err != nil {
+					return nil, err
+				}
+				parents = append(parents, list)
+			}
+			l, _ := x.(starlark.Indexable)
+			elems := make([]starlark.Value, l.Len())
+			for i := range elems {
+				conv, err := visit(append(path[:len(path):len(path)], pathSegment{index: i, isIndex: true}), l.Index(i), parents)
+				if err != nil {
+					return nil, err
+				}
+				elems[i] = conv
+			}
+			return starlark.NewList(elems), nil
+		default:
+			return starlark.Call(thread, fn, starlark.Tuple{starlark.String(formatPath(path)), v}, nil)
+		}
+	}
+	result, err := visit(nil, value, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return result, nil
+}
+
+// natural_sort
+
+var digitRunPattern = regexp.MustCompile(`[0-9]+|[^0-9]+`)
+
+// naturalLess compares strings so that runs of digits are ordered by their numeric value,
+// e.g. "node2" < "node10".
+func naturalLess(a, b string) bool {
+	as := digitRunPattern.FindAllString(a, -1)
+	bs := digitRunPattern.FindAllString(b, -1)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+		if x == y {
+			continue
+		}
+		if isDigit(x[0]) && isDigit(y[0]) {
+			tx, ty := strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(tx) != len(ty) {
+				return len(tx) < len(ty)
+			}
+			if tx != ty {
+				return tx < ty
+			}
+			// Equal numbers: fewer leading zeros sorts first.
+			return len(x) < len(y)
+		}
+		return x < y
+	}
+	return len(as) < len(bs)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func collectionsNaturalSort(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Iterable
+	var key starlark.Callable
+	reverse := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "values", &iterable, "key?", &key, "reverse?", &reverse); err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		value starlark.Value
+		key   string
+	}
+	var entries []entry
+	iter := iterable.Iterate()
+	defer iter.Done()
+	var v starlark.Value
+	for iter.Next(&v) {
+		k := v
+		if key != nil {
+			var err error
+			if k, err = starlark.Call(thread, key, starlark.Tuple{v}, nil); err != nil {
+				return nil, err
+			}
+		}
+		s, ok := starlark.AsString(k)
+		if !ok {
+			return nil, fmt.Errorf("%s: sort keys must be strings, got %s", b.Name(), k.Type())
+		}
+		entries = append(entries, entry{value: v, key: s})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if reverse {
+			return naturalLess(entries[j].key, entries[i].key)
+		}
+		return naturalLess(entries[i].key, entries[j].key)
+	})
+	values := make([]starlark.Value, len(entries))
+	for i, e := range entries {
+		values[i] = e.value
+	}
+	return starlark.NewList(values), nil
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCollectionsModule_deepMerge(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					base = {
+						tags  = { env = "dev", team = "web" }
+						zones = ["1"]
+						rules = [{ name = "http", port = 80 }, { name = "ssh", port = 22 }]
+					}
+					override = {
+						tags  = { env = "prod" }
+						zones = ["2"]
+						rules = [{ name = "http", port = 8080 }, { name = "https", port = 443 }]
+					}
+				}
+				output "replace" {
+					value = provider::starlark::eval(
+						"result = collections.deep_merge(base, override)",
+						{ base = local.base, override = local.override }
+					)
+				}
+				output "append" {
+					value = provider::starlark::eval(
+						"result = collections.deep_merge(base, override, lists = 'append')['zones']",
+						{ base = local.base, override = local.override }
+					)
+				}
+				output "merge_by_key" {
+					value = provider::starlark::eval(
+						"result = collections.deep_merge(base, override, lists = 'merge_by_key', key = 'name')['rules']",
+						{ base = local.base, override = local.override }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("replace", map[string]interface{}{
+						"tags":  map[string]interface{}{"env": "prod", "team": "web"},
+						"zones": []interface{}{"2"},
+						"rules": []interface{}{
+							map[string]interface{}{"name": "http", "port": json.Number("8080")},
+							map[string]interface{}{"name": "https", "port": json.Number("443")},
+						},
+					}),
+					NewTestCheckOutput("append", []interface{}{"1", "2"}),
+					NewTestCheckOutput("merge_by_key", []interface{}{
+						map[string]interface{}{"name": "http", "port": json.Number("8080")},
+						map[string]interface{}{"name": "ssh", "port": json.Number("22")},
+						map[string]interface{}{"name": "https", "port": json.Number("443")},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionsModule_paths(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					config = {
+						spec = { containers = [{ name = "web", image = "nginx" }] }
+						tags = { "kubernetes.io/name" = "web" }
+					}
+				}
+				output "get" {
+					value = provider::starlark::eval(
+						"result = [collections.get(v, 'spec.containers[0].image'), collections.get(v, 'tags[\"kubernetes.io/name\"]'), collections.get(v, 'spec.replicas', 1)]",
+						{ v = local.config }
+					)
+				}
+				output "set" {
+					value = provider::starlark::eval(
+						"result = collections.set(v, 'spec.containers[-1].image', 'nginx:1.27')['spec']",
+						{ v = local.config }
+					)
+				}
+				output "delete" {
+					value = provider::starlark::eval(
+						"result = collections.delete(v, 'spec')",
+						{ v = local.config }
+					)
+				}
+				output "pick_omit" {
+					value = provider::starlark::eval(
+						"result = [collections.pick(v, ['spec.containers', 'missing']), collections.omit(v, ['spec'])]",
+						{ v = local.config }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("get", []interface{}{"nginx", "web", json.Number("1")}),
+					NewTestCheckOutput("set", map[string]interface{}{
+						"containers": []interface{}{map[string]interface{}{"name": "web", "image": "nginx:1.27"}},
+					}),
+					NewTestCheckOutput("delete", map[string]interface{}{
+						"tags": map[string]interface{}{"kubernetes.io/name": "web"},
+					}),
+					NewTestCheckOutput("pick_omit", []interface{}{
+						map[string]interface{}{
+							"spec": map[string]interface{}{
+								"containers": []interface{}{map[string]interface{}{"name": "web", "image": "nginx"}},
+							},
+						},
+						map[string]interface{}{
+							"tags": map[string]interface{}{"kubernetes.io/name": "web"},
+						},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionsModule_flatten(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "flatten" {
+					value = provider::starlark::eval(
+						"result = collections.flatten({'db': {'host': 'x', 'ports': [1, 2]}}, sep = '__', lists = True)",
+						{}
+					)
+				}
+				output "unflatten" {
+					value = provider::starlark::eval(
+						"result = collections.unflatten({'db/host': 'x', 'db/port': 5432}, sep = '/')",
+						{}
+					)
+				}
+				output "walk" {
+					value = provider::starlark::eval(
+						"result = collections.walk({'db': {'password': 'secret', 'hosts': ['a']}}, lambda path, v: '***' if path.endswith('password') else v)",
+						{}
+					)
+				}
+				output "natural_sort" {
+					value = provider::starlark::eval(
+						"result = collections.natural_sort(['node10', 'node2', 'node1'])",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("flatten", map[string]interface{}{
+						"db__host":     "x",
+						"db__ports__0": json.Number("1"),
+						"db__ports__1": json.Number("2"),
+					}),
+					NewTestCheckOutput("unflatten", map[string]interface{}{
+						"db": map[string]interface{}{"host": "x", "port": json.Number("5432")},
+					}),
+					NewTestCheckOutput("walk", map[string]interface{}{
+						"db": map[string]interface{}{"password": "***", "hosts": []interface{}{"a"}},
+					}),
+					NewTestCheckOutput("natural_sort", []interface{}{"node1", "node2", "node10"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionsModule_invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = collections.deep_merge({}, {}, lists = 'merge_by_key')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`requires key`),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("x = {}\nx['self'] = x\nresult = collections.flatten(x)", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`cycle: dict contains itself`),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("x = [1]\nx.append({'list': x})\nresult = collections.walk(x, lambda path, v: v)", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`cycle: list contains itself`),
+			},
+		},
+	})
+}
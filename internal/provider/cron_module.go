@@ -0,0 +1,662 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// cronModule implements the "cron" module for standard, Quartz and AWS cron expressions. Fire times
+// are always computed from a reference instant supplied by the script, never from the clock.
+var cronModule = &starlarkstruct.Module{
+	Name: "cron",
+	Members: starlark.StringDict{
+		"parse":    starlark.NewBuiltin("cron.parse", cronParse),
+		"validate": starlark.NewBuiltin("cron.validate", cronValidate),
+		"convert":  starlark.NewBuiltin("cron.convert", cronConvert),
+		"next":     starlark.NewBuiltin("cron.next", cronNext),
+	},
+}
+
+const (
+	cronStandard = "standard"
+	cronQuartz   = "quartz"
+	cronAWS      = "aws"
+)
+
+// Indexes of the fields of a cronSchedule. Dialects without seconds or years use "0" and "*".
+const (
+	cronSecond = iota
+	cronMinute
+	cronHour
+	cronDayOfMonth
+	cronMonth
+	cronDayOfWeek
+	cronYear
+)
+
+var cronFieldNames = []string{"second", "minute", "hour", "day-of-month", "month", "day-of-week", "year"}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	// Standard cron numbers weekdays from Sunday = 0 (7 is also Sunday), Quartz and AWS from Sunday = 1.
+	cronStandardWeekdays = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+	cronQuartzWeekdays   = map[string]int{"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7}
+)
+
+type cronFieldSpec struct {
+	min, max int
+	names    map[string]int
+}
+
+func cronFieldSpecs(dialect string) []cronFieldSpec {
+	specs := []cronFieldSpec{
+		{min: 0, max: 59},
+		{min: 0, max: 59},
+		{min: 0, max: 23},
+		{min: 1, max: 31},
+		{min: 1, max: 12, names: cronMonthNames},
+		{min: 1, max: 7, names: cronQuartzWeekdays},
+		{min: 1970, max: 2099},
+	}
+	switch dialect {
+	case cronStandard:
+		specs[cronDayOfWeek] = cronFieldSpec{min: 0, max: 7, names: cronStandardWeekdays}
+	case cronAWS:
+		specs[cronYear].max = 2199
+	}
+	return specs
+}
+
+var (
+	cronLastOffsetPattern = regexp.MustCompile(`^L(?:-(\d+))?$`)
+	cronNearestPattern    = regexp.MustCompile(`^(\d+)W$`)
+	cronLastWeekdayOf     = regexp.MustCompile(`^(\w+)L$`)
+	cronNthWeekdayPattern = regexp.MustCompile(`^(\w+)#(\d)$`)
+)
+
+// cronSchedule is a parsed cron expression. Sets are indexed by value; weekdays are 0 (Sunday)
+// to 6 in every dialect.
+type cronSchedule struct {
+	dialect string
+	fields  [7]string
+	sets    [7][]bool
+
+	dayOfMonthAny, dayOfWeekAny   bool // "?" in Quartz and AWS
+	dayOfMonthStar, dayOfWeekStar bool // the field starts with "*", which selects AND instead of OR in standard cron
+
+	dayOfMonthRule string // "L", "L-n", "LW" or "nW"
+	lastOffset     int    // n in "L-n"
+	nearestDay     int    // n in "nW"
+	dayOfWeekRule  string // "nL" or "n#k"
+	ruleWeekday    int
+	ruleNth        int
+
+	maxYear int
+}
+
+func parseCron(expr, dialect string) (*cronSchedule, error) {
+	s := &cronSchedule{dialect: dialect}
+	text := strings.TrimSpace(expr)
+	var fields []string
+	switch dialect {
+	case cronStandard:
+		if strings.HasPrefix(text, "@") {
+			macro, ok := cronMacros[strings.ToLower(text)]
+			if !ok {
+				return nil, fmt.Errorf("unsupported macro %q", text)
+			}
+			text = macro
+		}
+		fields = strings.Fields(text)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("got %d fields, want 5 (minute hour day-of-month month day-of-week)", len(fields))
+		}
+		fields = append(append([]string{"0"}, fields...), "*")
+	case cronQuartz:
+		fields = strings.Fields(text)
+		if len(fields) != 6 && len(fields) != 7 {
+			return nil, fmt.Errorf("got %d fields, want 6 or 7 (second minute hour day-of-month month day-of-week [year])", len(fields))
+		}
+		if len(fields) == 6 {
+			fields = append(fields, "*")
+		}
+	case cronAWS:
+		if strings.HasPrefix(text, "cron(") && strings.HasSuffix(text, ")") {
+			text = text[len("cron(") : len(text)-1]
+		}
+		fields = strings.Fields(text)
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("got %d fields, want 6 (minute hour day-of-month month day-of-week year)", len(fields))
+		}
+		fields = append([]string{"0"}, fields...)
+	default:
+		return nil, fmt.Errorf("unknown dialect %q, want standard, quartz or aws", dialect)
+	}
+	copy(s.fields[:], fields)
+
+	specs := cronFieldSpecs(dialect)
+	s.maxYear = specs[cronYear].max
+	for i, field := range s.fields {
+		var err error
+		switch {
+		case i == cronDayOfMonth && dialect != cronStandard && field == "?":
+			s.dayOfMonthAny = true
+		case i == cronDayOfWeek && dialect != cronStandard && field == "?":
+			s.dayOfWeekAny = true
+		case i == cronDayOfMonth && dialect != cronStandard && strings.ContainsAny(field, "LW"):
+			err = s.parseDayOfMonthRule(field)
+		case i == cronDayOfWeek && dialect != cronStandard && strings.ContainsAny(field, "L#") && field != "L":
+			err = s.parseDayOfWeekRule(field, specs[i])
+		case i == cronDayOfWeek && dialect != cronStandard && field == "L":
+			s.sets[i] = parseCronMust("7", specs[i])
+		case i == cronYear && field == "*":
+			// any year
+		default:
+			s.sets[i], err = parseCronField(field, specs[i])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s field %q: %s", cronFieldNames[i], field, err)
+		}
+	}
+	s.dayOfMonthStar = strings.HasPrefix(s.fields[cronDayOfMonth], "*")
+	s.dayOfWeekStar = strings.HasPrefix(s.fields[cronDayOfWeek], "*")
+
+	if dialect != cronStandard {
+		switch {
+		case s.dayOfMonthAny && s.dayOfWeekAny:
+			return nil, fmt.Errorf("only one of day-of-month and day-of-week can be ?")
+		case !s.dayOfMonthAny && !s.dayOfWeekAny:
+			return nil, fmt.Errorf("one of day-of-month and day-of-week must be ?")
+		}
+	}
+
+	// Normalize weekdays to Sunday = 0.
+	if set := s.sets[cronDayOfWeek]; set != nil {
+		weekdays := make([]bool, 7)
+		for v, ok := range set {
+			if !ok {
+				continue
+			}
+			if dialect == cronStandard {
+				weekdays[v%7] = true
+			} else {
+				weekdays[v-1] = true
+			}
+		}
+		s.sets[cronDayOfWeek] = weekdays
+	}
+	return s, nil
+}
+
+func parseCronMust(field string, spec cronFieldSpec) []bool {
+	set, _ := parseCronField(field, spec)
+	return set
+}
+
+// parseCronField parses a comma-separated list of "*", values and ranges, each with an optional
+// "/step", into a set indexed by value.
+func parseCronField(field string, spec cronFieldSpec) ([]bool, error) {
+	set := make([]bool, spec.max+1)
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = spec.min, spec.max
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = cronValue(a, spec); err != nil {
+				return nil, err
+			}
+			if hi, err = cronValue(b, spec); err != nil {
+				return nil, err
+			}
+			if lo > hi {
+				return nil, fmt.Errorf("range %q ends before it starts", rangePart)
+			}
+		default:
+			v, err := cronValue(rangePart, spec)
+			if err != nil {
+				return nil, err
+			}
+			lo, hi = v, v
+			if hasStep {
+				hi = spec.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func cronValue(s string, spec cronFieldSpec) (int, error) {
+	if v, ok := spec.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < spec.min || v > spec.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, spec.min, spec.max)
+	}
+	return v, nil
+}
+
+func (s *cronSchedule) parseDayOfMonthRule(field string) error {
+	switch {
+	case field == "LW":
+	case cronLastOffsetPattern.MatchString(field):
+		if m := cronLastOffsetPattern.FindStringSubmatch(field); m[1] != "" {
+			n, _ := strconv.Atoi(m[1])
+			if n < 1 || n > 30 {
+				return fmt.Errorf("offset %d out of range 1-30", n)
+			}
+			s.lastOffset = n
+		}
+	case cronNearestPattern.MatchString(field):
+		n, _ := strconv.Atoi(cronNearestPattern.FindStringSubmatch(field)[1])
+		if n < 1 || n > 31 {
+			return fmt.Errorf("day %d out of range 1-31", n)
+		}
+		s.nearestDay = n
+	default:
+		return fmt.Errorf("want L, L-n, LW or nW")
+	}
+	s.dayOfMonthRule = field
+	return nil
+}
+
+func (s *cronSchedule) parseDayOfWeekRule(field string, spec cronFieldSpec) error {
+	if m := cronNthWeekdayPattern.FindStringSubmatch(field); m != nil {
+		v, err := cronValue(m[1], spec)
+		if err != nil {
+			return err
+		}
+		n, _ := strconv.Atoi(m[2])
+		if n < 1 || n > 5 {
+			return fmt.Errorf("occurrence %d out of range 1-5", n)
+		}
+		s.ruleWeekday, s.ruleNth = v-1, n
+	} else if m := cronLastWeekdayOf.FindStringSubmatch(field); m != nil {
+		v, err := cronValue(m[1], spec)
+		if err != nil {
+			return err
+		}
+		s.ruleWeekday = v - 1
+	} else {
+		return fmt.Errorf("want nL or n#k")
+	}
+	s.dayOfWeekRule = field
+	return nil
+}
+
+func lastDayOfMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (s *cronSchedule) dayOfMonthMatches(year int, month time.Month, day int) bool {
+	last := lastDayOfMonth(year, month)
+	switch {
+	case s.dayOfMonthRule == "":
+		return s.sets[cronDayOfMonth][day]
+	case s.dayOfMonthRule == "LW":
+		target := last
+		switch time.Date(year, month, last, 0, 0, 0, 0, time.UTC).Weekday() {
+		case time.Saturday:
+			target = last - 1
+		case time.Sunday:
+			target = last - 2
+		}
+		return day == target
+	case s.nearestDay > 0:
+		target := s.nearestDay
+		if target > last {
+			return false
+		}
+		switch time.Date(year, month, target, 0, 0, 0, 0, time.UTC).Weekday() {
+		case time.Saturday:
+			if target == 1 {
+				target += 2
+			} else {
+				target--
+			}
+		case time.Sunday:
+			if target == last {
+				target -= 2
+			} else {
+				target++
+			}
+		}
+		return day == target
+	default:
+		return day == last-s.lastOffset
+	}
+}
+
+func (s *cronSchedule) dayOfWeekMatches(year int, month time.Month, day int, weekday time.Weekday) bool {
+	switch {
+	case s.dayOfWeekRule == "":
+		return s.sets[cronDayOfWeek][weekday]
+	case int(weekday) != s.ruleWeekday:
+		return false
+	case s.ruleNth > 0:
+		return (day-1)/7+1 == s.ruleNth
+	default:
+		return day+7 > lastDayOfMonth(year, month)
+	}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	year, month, day := t.Date()
+	switch {
+	case s.dayOfMonthAny:
+		return s.dayOfWeekMatches(year, month, day, t.Weekday())
+	case s.dayOfWeekAny:
+		return s.dayOfMonthMatches(year, month, day)
+	case s.dayOfMonthStar || s.dayOfWeekStar:
+		return s.dayOfMonthMatches(year, month, day) && s.dayOfWeekMatches(year, month, day, t.Weekday())
+	default:
+		// Standard cron fires when either day field matches if both are restricted.
+		return s.dayOfMonthMatches(year, month, day) || s.dayOfWeekMatches(year, month, day, t.Weekday())
+	}
+}
+
+// next returns up to count fire times after from, in from's time zone. The search ends at the last
+// year the dialect supports, or 400 years after from for standard cron, so a schedule that never
+// fires, such as February 30, returns fewer times.
+func (s *cronSchedule) next(from time.Time, count int) []time.Time {
+	loc := from.Location()
+	resolution := time.Minute
+	if s.dialect == cronQuartz {
+		resolution = time.Second
+	}
+	maxYear := s.maxYear
+	if s.dialect == cronStandard {
+		maxYear = from.Year() + 400
+	}
+
+	var times []time.Time
+	t := from.Truncate(resolution).Add(resolution)
+	for len(times) < count && t.Year() <= maxYear {
+		year, month, day := t.Date()
+		switch {
+		case s.sets[cronYear] != nil && (year >= len(s.sets[cronYear]) || !s.sets[cronYear][year]):
+			t = time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+		case !s.sets[cronMonth][month]:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case !s.sets[cronHour][t.Hour()]:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+		case !s.sets[cronMinute][t.Minute()]:
+			t = time.Date(year, month, day, t.Hour(), t.Minute()+1, 0, 0, loc)
+		case !s.sets[cronSecond][t.Second()]:
+			t = t.Add(time.Second)
+		default:
+			times = append(times, t)
+			t = t.Add(resolution)
+		}
+	}
+	return times
+}
+
+func allSet(set []bool, min, max int) bool {
+	for v := min; v <= max; v++ {
+		if !set[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatWeekdays formats a Sunday = 0 weekday set as a list of values and ranges numbered from base.
+func formatWeekdays(set []bool, base int) string {
+	var items []string
+	for start := 0; start < 7; {
+		if !set[start] {
+			start++
+			continue
+		}
+		end := start
+		for end+1 < 7 && set[end+1] {
+			end++
+		}
+		switch {
+		case end-start >= 2:
+			items = append(items, fmt.Sprintf("%d-%d", start+base, end+base))
+		case end > start:
+			items = append(items, strconv.Itoa(start+base), strconv.Itoa(end+base))
+		default:
+			items = append(items, strconv.Itoa(start+base))
+		}
+		start = end + 1
+	}
+	return strings.Join(items, ",")
+}
+
+// standardSteps rewrites "a/n" items, which not every standard cron accepts, as "*/n" or "a-max/n".
+func standardSteps(field string, min, max int) string {
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		rangePart, step, ok := strings.Cut(item, "/")
+		switch {
+		case !ok || rangePart == "*" || strings.Contains(rangePart, "-"):
+		case rangePart == strconv.Itoa(min):
+			items[i] = "*/" + step
+		default:
+			items[i] = fmt.Sprintf("%s-%d/%s", rangePart, max, step)
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// convert formats the schedule in another dialect. It fails if the target cannot express the
+// schedule exactly.
+func (s *cronSchedule) convert(dialect string) (string, error) {
+	f := s.fields
+	dayOfMonth, dayOfWeek := f[cronDayOfMonth], f[cronDayOfWeek]
+
+	if dialect == cronStandard {
+		switch {
+		case f[cronSecond] != "0":
+			return "", fmt.Errorf("standard cron has no seconds field, seconds must be 0")
+		case f[cronYear] != "*":
+			return "", fmt.Errorf("standard cron has no year field, year must be *")
+		case s.dayOfMonthRule != "":
+			return "", fmt.Errorf("standard cron does not support day-of-month %q", s.dayOfMonthRule)
+		case s.dayOfWeekRule != "":
+			return "", fmt.Errorf("standard cron does not support day-of-week %q", s.dayOfWeekRule)
+		}
+		if s.dayOfMonthAny {
+			dayOfMonth = "*"
+		}
+		if s.dayOfWeekAny || allSet(s.sets[cronDayOfWeek], 0, 6) {
+			dayOfWeek = "*"
+		} else if s.dialect != cronStandard {
+			dayOfWeek = formatWeekdays(s.sets[cronDayOfWeek], 0)
+		}
+		return strings.Join([]string{
+			standardSteps(f[cronMinute], 0, 59),
+			standardSteps(f[cronHour], 0, 23),
+			standardSteps(dayOfMonth, 1, 31),
+			standardSteps(f[cronMonth], 1, 12),
+			dayOfWeek,
+		}, " "), nil
+	}
+
+	if s.dialect == cronStandard {
+		allDays := allSet(s.sets[cronDayOfMonth], 1, 31)
+		allWeekdays := allSet(s.sets[cronDayOfWeek], 0, 6)
+		and := s.dayOfMonthStar || s.dayOfWeekStar
+		switch {
+		case and && allWeekdays:
+			dayOfWeek = "?"
+		case and && allDays:
+			dayOfMonth, dayOfWeek = "?", formatWeekdays(s.sets[cronDayOfWeek], 1)
+		case allDays || allWeekdays:
+			// Either list matching every day makes the OR of the two fields match every day.
+			dayOfMonth, dayOfWeek = "*", "?"
+		default:
+			return "", fmt.Errorf("%s cron cannot restrict both day-of-month and day-of-week", dialect)
+		}
+	}
+
+	var result string
+	switch dialect {
+	case cronQuartz:
+		fields := []string{f[cronSecond], f[cronMinute], f[cronHour], dayOfMonth, f[cronMonth], dayOfWeek}
+		if f[cronYear] != "*" {
+			fields = append(fields, f[cronYear])
+		}
+		result = strings.Join(fields, " ")
+	case cronAWS:
+		if f[cronSecond] != "0" {
+			return "", fmt.Errorf("AWS cron has no seconds field, seconds must be 0")
+		}
+		result = "cron(" + strings.Join([]string{f[cronMinute], f[cronHour], dayOfMonth, f[cronMonth], dayOfWeek, f[cronYear]}, " ") + ")"
+	default:
+		return "", fmt.Errorf("unknown dialect %q, want standard, quartz or aws", dialect)
+	}
+	if _, err := parseCron(result, dialect); err != nil {
+		return "", fmt.Errorf("%s cron cannot express %q: %s", dialect, result, err)
+	}
+	return result, nil
+}
+
+func cronSetToList(set []bool) starlark.Value {
+	if set == nil {
+		return starlark.None
+	}
+	var values []starlark.Value
+	for v, ok := range set {
+		if ok {
+			values = append(values, starlark.MakeInt(v))
+		}
+	}
+	return starlark.NewList(values)
+}
+
+// cronParse returns the values each field of a cron expression selects.
+func cronParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var expr string
+	dialect := cronStandard
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "expr", &expr, "dialect?", &dialect); err != nil {
+		return nil, err
+	}
+	s, err := parseCron(expr, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	d := starlark.NewDict(10)
+	_ = d.SetKey(starlark.String("dialect"), starlark.String(dialect))
+	_ = d.SetKey(starlark.String("seconds"), cronSetToList(s.sets[cronSecond]))
+	_ = d.SetKey(starlark.String("minutes"), cronSetToList(s.sets[cronMinute]))
+	_ = d.SetKey(starlark.String("hours"), cronSetToList(s.sets[cronHour]))
+	_ = d.SetKey(starlark.String("days_of_month"), cronSetToList(s.sets[cronDayOfMonth]))
+	_ = d.SetKey(starlark.String("months"), cronSetToList(s.sets[cronMonth]))
+	_ = d.SetKey(starlark.String("days_of_week"), cronSetToList(s.sets[cronDayOfWeek]))
+	_ = d.SetKey(starlark.String("years"), cronSetToList(s.sets[cronYear]))
+	_ = d.SetKey(starlark.String("day_of_month_rule"), optionalString(s.dayOfMonthRule))
+	_ = d.SetKey(starlark.String("day_of_week_rule"), optionalString(s.dayOfWeekRule))
+	return d, nil
+}
+
+// cronValidate returns None if the expression is valid, or a message explaining why it is not.
+func cronValidate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var expr string
+	dialect := cronStandard
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "expr", &expr, "dialect?", &dialect); err != nil {
+		return nil, err
+	}
+	switch dialect {
+	case cronStandard, cronQuartz, cronAWS:
+	default:
+		return nil, fmt.Errorf("%s: unknown dialect %q, want standard, quartz or aws", b.Name(), dialect)
+	}
+	if _, err := parseCron(expr, dialect); err != nil {
+		return starlark.String(err.Error()), nil
+	}
+	return starlark.None, nil
+}
+
+func cronConvert(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var expr, from, to string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "expr", &expr, "from_dialect", &from, "to_dialect", &to); err != nil {
+		return nil, err
+	}
+	s, err := parseCron(expr, from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	result, err := s.convert(to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(result), nil
+}
+
+// cronNext returns the next count fire times strictly after the RFC 3339 timestamp from, formatted
+// in the same UTC offset.
+func cronNext(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var expr, from string
+	count := 1
+	dialect := cronStandard
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "expr", &expr, "from", &from, "count?", &count, "dialect?", &dialect, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("%s: count must not be negative", b.Name())
+	}
+	if err := checkMaxItems(float64(count), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	t, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: from: %s", b.Name(), err)
+	}
+	s, err := parseCron(expr, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	times := s.next(t, count)
+	values := make([]starlark.Value, len(times))
+	for i, t := range times {
+		values[i] = starlark.String(t.Format(time.RFC3339))
+	}
+	return starlark.NewList(values), nil
+}
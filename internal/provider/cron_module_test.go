@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCronModule_next(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "standard" {
+					value = provider::starlark::eval("result = cron.next('*/15 9-17 * * MON-FRI', '2025-03-07T16:50:00Z', 3)", {})
+				}
+				output "day_or_weekday" {
+					value = provider::starlark::eval("result = cron.next('0 0 1,15 * 1', '2025-03-01T00:00:00Z', 4)", {})
+				}
+				output "offset" {
+					value = provider::starlark::eval("result = cron.next('0 0 29 2 *', '2025-01-01T00:00:00+02:00', 2)", {})
+				}
+				output "never" {
+					value = provider::starlark::eval("result = cron.next('0 0 30 2 *', '2025-01-01T00:00:00Z', 2)", {})
+				}
+				output "quartz_nth_weekday" {
+					value = provider::starlark::eval("result = cron.next('0 15 10 ? * 6#3', '2025-01-01T00:00:00Z', 2, dialect = 'quartz')", {})
+				}
+				output "aws_nearest_weekday" {
+					value = provider::starlark::eval("result = cron.next('cron(0 3 15W * ? *)', '2025-01-01T00:00:00Z', 3, dialect = 'aws')", {})
+				}
+				output "aws_last_friday" {
+					value = provider::starlark::eval("result = cron.next('cron(0 3 ? * 6L 2025)', '2025-11-01T00:00:00Z', 3, dialect = 'aws')", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("standard", []interface{}{"2025-03-07T17:00:00Z", "2025-03-07T17:15:00Z", "2025-03-07T17:30:00Z"}),
+					NewTestCheckOutput("day_or_weekday", []interface{}{"2025-03-03T00:00:00Z", "2025-03-10T00:00:00Z", "2025-03-15T00:00:00Z", "2025-03-17T00:00:00Z"}),
+					NewTestCheckOutput("offset", []interface{}{"2028-02-29T00:00:00+02:00", "2032-02-29T00:00:00+02:00"}),
+					NewTestCheckOutput("never", []interface{}{}),
+					NewTestCheckOutput("quartz_nth_weekday", []interface{}{"2025-01-17T10:15:00Z", "2025-02-21T10:15:00Z"}),
+					NewTestCheckOutput("aws_nearest_weekday", []interface{}{"2025-01-15T03:00:00Z", "2025-02-14T03:00:00Z", "2025-03-14T03:00:00Z"}),
+					NewTestCheckOutput("aws_last_friday", []interface{}{"2025-11-28T03:00:00Z", "2025-12-26T03:00:00Z"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCronModule_parseAndValidate(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parsed" {
+					value = provider::starlark::eval(<<-EOT
+					p = cron.parse('cron(0/30 12 ? * MON-FRI 2025-2026)', 'aws')
+					result = {k: p[k] for k in ['minutes', 'hours', 'days_of_month', 'days_of_week', 'years']}
+					EOT
+					, {})
+				}
+				output "valid" {
+					value = provider::starlark::eval("result = cron.validate('0 0 12 * * ?', 'quartz')", {})
+				}
+				output "out_of_range" {
+					value = provider::starlark::eval("result = cron.validate('61 * * * *')", {})
+				}
+				output "both_days" {
+					value = provider::starlark::eval("result = cron.validate('0 0 12 1 * MON', 'quartz')", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parsed", map[string]interface{}{
+						"minutes":       []interface{}{json.Number("0"), json.Number("30")},
+						"hours":         []interface{}{json.Number("12")},
+						"days_of_month": nil,
+						"days_of_week":  []interface{}{json.Number("1"), json.Number("2"), json.Number("3"), json.Number("4"), json.Number("5")},
+						"years":         []interface{}{json.Number("2025"), json.Number("2026")},
+					}),
+					NewTestCheckOutput("valid", nil),
+					resource.TestCheckOutput("out_of_range", `minute field "61": value 61 out of range 0-59`),
+					resource.TestCheckOutput("both_days", "one of day-of-month and day-of-week must be ?"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCronModule_convert(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "standard_to_aws" {
+					value = provider::starlark::eval("result = cron.convert('30 2 * * 1-5', 'standard', 'aws')", {})
+				}
+				output "standard_to_quartz" {
+					value = provider::starlark::eval("result = cron.convert('@daily', 'standard', 'quartz')", {})
+				}
+				output "aws_to_standard" {
+					value = provider::starlark::eval("result = cron.convert('cron(0 12 ? * 2-6 *)', 'aws', 'standard')", {})
+				}
+				output "quartz_to_standard" {
+					value = provider::starlark::eval("result = cron.convert('0 0/5 * * * ?', 'quartz', 'standard')", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("standard_to_aws", "cron(30 2 ? * 2-6 *)"),
+					resource.TestCheckOutput("standard_to_quartz", "0 0 0 * * ?"),
+					resource.TestCheckOutput("aws_to_standard", "0 12 * * 1-5"),
+					resource.TestCheckOutput("quartz_to_standard", "*/5 * * * *"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCronModule_convertUnsupported(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = cron.convert('0 0 0 L * ?', 'quartz', 'standard')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`does not support`),
+			},
+		},
+	})
+}
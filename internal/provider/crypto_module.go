@@ -0,0 +1,299 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"golang.org/x/crypto/ssh"
+)
+
+// cryptoModule implements the "crypto" module for inspecting certificates, SSH public keys and JWTs.
+// It only parses: nothing is signed, verified or generated.
+var cryptoModule = &starlarkstruct.Module{
+	Name: "crypto",
+	Members: starlark.StringDict{
+		"parse_certificate":     starlark.NewBuiltin("crypto.parse_certificate", cryptoParseCertificate),
+		"parse_certificates":    starlark.NewBuiltin("crypto.parse_certificates", cryptoParseCertificates),
+		"parse_ssh_public_key":  starlark.NewBuiltin("crypto.parse_ssh_public_key", cryptoParseSSHPublicKey),
+		"parse_authorized_keys": starlark.NewBuiltin("crypto.parse_authorized_keys", cryptoParseAuthorizedKeys),
+		"decode_jwt":            starlark.NewBuiltin("crypto.decode_jwt", cryptoDecodeJWT),
+	},
+}
+
+// parseCertificates returns the certificates in data, which is either PEM with one or more
+// CERTIFICATE blocks or a single DER-encoded certificate. Other PEM blocks, such as keys, are skipped.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := data
+	sawPEM := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		sawPEM = true
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %s", len(certs), err)
+		}
+		certs = append(certs, cert)
+	}
+	if !sawPEM {
+		cert, err := x509.ParseCertificate(data)
+		if err != nil {
+			return nil, fmt.Errorf("no PEM data found, and not a DER certificate: %s", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no CERTIFICATE blocks found")
+	}
+	return certs, nil
+}
+
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                        "any",
+	x509.ExtKeyUsageServerAuth:                 "server_auth",
+	x509.ExtKeyUsageClientAuth:                 "client_auth",
+	x509.ExtKeyUsageCodeSigning:                "code_signing",
+	x509.ExtKeyUsageEmailProtection:            "email_protection",
+	x509.ExtKeyUsageIPSECEndSystem:             "ipsec_end_system",
+	x509.ExtKeyUsageIPSECTunnel:                "ipsec_tunnel",
+	x509.ExtKeyUsageIPSECUser:                  "ipsec_user",
+	x509.ExtKeyUsageTimeStamping:               "timestamping",
+	x509.ExtKeyUsageOCSPSigning:                "ocsp_signing",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto: "microsoft_server_gated_crypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:  "netscape_server_gated_crypto",
+}
+
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "digital_signature"},
+	{x509.KeyUsageContentCommitment, "content_commitment"},
+	{x509.KeyUsageKeyEncipherment, "key_encipherment"},
+	{x509.KeyUsageDataEncipherment, "data_encipherment"},
+	{x509.KeyUsageKeyAgreement, "key_agreement"},
+	{x509.KeyUsageCertSign, "cert_sign"},
+	{x509.KeyUsageCRLSign, "crl_sign"},
+	{x509.KeyUsageEncipherOnly, "encipher_only"},
+	{x509.KeyUsageDecipherOnly, "decipher_only"},
+}
+
+// publicKeyInfo returns the type and size in bits of a public key.
+func publicKeyInfo(key interface{}) (string, starlark.Value) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return "RSA", starlark.MakeInt(k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA", starlark.MakeInt(k.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return "Ed25519", starlark.MakeInt(256)
+	default:
+		return fmt.Sprintf("%T", key), starlark.None
+	}
+}
+
+func stringList(values []string) *starlark.List {
+	list := make([]starlark.Value, len(values))
+	for i, v := range values {
+		list[i] = starlark.String(v)
+	}
+	return starlark.NewList(list)
+}
+
+func certificateToStarlark(cert *x509.Certificate) *starlark.Dict {
+	var ips, uris, extUsages, usages []string
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	for _, u := range cert.URIs {
+		uris = append(uris, u.String())
+	}
+	for _, u := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[u]; ok {
+			extUsages = append(extUsages, name)
+		}
+	}
+	for _, u := range keyUsageNames {
+		if cert.KeyUsage&u.usage != 0 {
+			usages = append(usages, u.name)
+		}
+	}
+	keyType, keySize := publicKeyInfo(cert.PublicKey)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	sha1Sum := sha1.Sum(cert.Raw)
+
+	d := starlark.NewDict(20)
+	_ = d.SetKey(starlark.String("subject"), starlark.String(cert.Subject.String()))
+	_ = d.SetKey(starlark.String("common_name"), starlark.String(cert.Subject.CommonName))
+	_ = d.SetKey(starlark.String("issuer"), starlark.String(cert.Issuer.String()))
+	_ = d.SetKey(starlark.String("serial_number"), starlark.String(hex.EncodeToString(cert.SerialNumber.Bytes())))
+	_ = d.SetKey(starlark.String("not_before"), starlark.String(cert.NotBefore.UTC().Format(time.RFC3339)))
+	_ = d.SetKey(starlark.String("not_after"), starlark.String(cert.NotAfter.UTC().Format(time.RFC3339)))
+	_ = d.SetKey(starlark.String("dns_names"), stringList(cert.DNSNames))
+	_ = d.SetKey(starlark.String("ip_addresses"), stringList(ips))
+	_ = d.SetKey(starlark.String("email_addresses"), stringList(cert.EmailAddresses))
+	_ = d.SetKey(starlark.String("uris"), stringList(uris))
+	_ = d.SetKey(starlark.String("is_ca"), starlark.Bool(cert.IsCA))
+	_ = d.SetKey(starlark.String("self_signed"), starlark.Bool(isSelfSigned(cert)))
+	_ = d.SetKey(starlark.String("key_type"), starlark.String(keyType))
+	_ = d.SetKey(starlark.String("key_size"), keySize)
+	_ = d.SetKey(starlark.String("signature_algorithm"), starlark.String(cert.SignatureAlgorithm.String()))
+	_ = d.SetKey(starlark.String("key_usage"), stringList(usages))
+	_ = d.SetKey(starlark.String("ext_key_usage"), stringList(extUsages))
+	_ = d.SetKey(starlark.String("fingerprint_sha256"), starlark.String(hex.EncodeToString(sha256Sum[:])))
+	_ = d.SetKey(starlark.String("fingerprint_sha1"), starlark.String(hex.EncodeToString(sha1Sum[:])))
+	return d
+}
+
+// isSelfSigned reports whether cert is signed by its own key. CheckSignatureFrom is not used
+// because it rejects parents that are not CAs, which would miss self-signed leaf certificates.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// cryptoParseCertificate returns the first certificate in a PEM bundle, normally the leaf.
+func cryptoParseCertificate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "pem", &data); err != nil {
+		return nil, err
+	}
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return certificateToStarlark(certs[0]), nil
+}
+
+// cryptoParseCertificates returns every certificate in a PEM bundle, in order.
+func cryptoParseCertificates(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "pem", &data); err != nil {
+		return nil, err
+	}
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	values := make([]starlark.Value, len(certs))
+	for i, cert := range certs {
+		values[i] = certificateToStarlark(cert)
+	}
+	return starlark.NewList(values), nil
+}
+
+func sshPublicKeyToStarlark(key ssh.PublicKey, comment string, options []string) *starlark.Dict {
+	keySize := starlark.Value(starlark.None)
+	if ck, ok := key.(ssh.CryptoPublicKey); ok {
+		_, keySize = publicKeyInfo(ck.CryptoPublicKey())
+	}
+	md5Sum := md5.Sum(key.Marshal())
+	md5Hex := make([]string, len(md5Sum))
+	for i, c := range md5Sum {
+		md5Hex[i] = fmt.Sprintf("%02x", c)
+	}
+
+	d := starlark.NewDict(7)
+	_ = d.SetKey(starlark.String("type"), starlark.String(key.Type()))
+	_ = d.SetKey(starlark.String("key_size"), keySize)
+	_ = d.SetKey(starlark.String("comment"), starlark.String(comment))
+	_ = d.SetKey(starlark.String("options"), stringList(options))
+	_ = d.SetKey(starlark.String("fingerprint_sha256"), starlark.String(ssh.FingerprintSHA256(key)))
+	_ = d.SetKey(starlark.String("fingerprint_md5"), starlark.String(strings.Join(md5Hex, ":")))
+	_ = d.SetKey(starlark.String("key"), starlark.String(strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))))
+	return d
+}
+
+// cryptoParseSSHPublicKey parses a single authorized_keys line, such as the contents of id_ed25519.pub.
+func cryptoParseSSHPublicKey(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var line string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "line", &line); err != nil {
+		return nil, err
+	}
+	key, comment, options, rest, err := ssh.ParseAuthorizedKey([]byte(line))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("%s: got more than one key, use crypto.parse_authorized_keys", b.Name())
+	}
+	return sshPublicKeyToStarlark(key, comment, options), nil
+}
+
+// cryptoParseAuthorizedKeys parses an authorized_keys file. Blank lines and comments are skipped,
+// but any other line that is not a valid key is an error.
+func cryptoParseAuthorizedKeys(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var text string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "text", &text); err != nil {
+		return nil, err
+	}
+	var keys []starlark.Value
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, comment, options, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: %s", b.Name(), i+1, err)
+		}
+		keys = append(keys, sshPublicKeyToStarlark(key, comment, options))
+	}
+	return starlark.NewList(keys), nil
+}
+
+// cryptoDecodeJWT decodes the header and payload of a JSON Web Token. The signature is NOT verified,
+// so the claims must not be trusted for authorization decisions.
+func cryptoDecodeJWT(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var token string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "token", &token); err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%s: got %d segments, want 3 (header.payload.signature)", b.Name(), len(parts))
+	}
+
+	d := starlark.NewDict(3)
+	for i, name := range []string{"header", "payload"} {
+		data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[i], "="))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %s", b.Name(), name, err)
+		}
+		v, err := starlark.Call(thread, json.Module.Members["decode"], starlark.Tuple{starlark.String(data)}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %s", b.Name(), name, err)
+		}
+		if _, ok := v.(*starlark.Dict); !ok {
+			return nil, fmt.Errorf("%s: %s: got %s, want JSON object", b.Name(), name, v.Type())
+		}
+		_ = d.SetKey(starlark.String(name), v)
+	}
+	_ = d.SetKey(starlark.String("signature"), starlark.String(parts[2]))
+	return d, nil
+}
@@ -0,0 +1,234 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// csvModule implements the "csv" module.
+var csvModule = &starlarkstruct.Module{
+	Name: "csv",
+	Members: starlark.StringDict{
+		"parse":  starlark.NewBuiltin("csv.parse", csvParse),
+		"encode": starlark.NewBuiltin("csv.encode", csvEncode),
+	},
+}
+
+// csvParse parses CSV text. With a header row, each record becomes a dict keyed by column name;
+// otherwise each record becomes a list of fields.
+func csvParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	delimiter := ","
+	header := true
+	inferTypes := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "delimiter?", &delimiter, "header?", &header, "infer_types?", &inferTypes); err != nil {
+		return nil, err
+	}
+	comma, err := csvDelimiter(delimiter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	r := csv.NewReader(strings.NewReader(s))
+	r.Comma = comma
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	field := func(v string) starlark.Value {
+		if inferTypes {
+			return inferCSVValue(v)
+		}
+		return starlark.String(v)
+	}
+
+	rows := make([]starlark.Value, 0, len(records))
+	if !header {
+		for _, record := range records {
+			fields := make([]starlark.Value, 0, len(record))
+			for _, v := range record {
+				fields = append(fields, field(v))
+			}
+			rows = append(rows, starlark.NewList(fields))
+		}
+		return starlark.NewList(rows), nil
+	}
+
+	if len(records) == 0 {
+		return starlark.NewList(nil), nil
+	}
+	columns := records[0]
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		if seen[c] {
+			return nil, fmt.Errorf("%s: duplicate column %q in header", b.Name(), c)
+		}
+		seen[c] = true
+	}
+	for _, record := range records[1:] {
+		row := starlark.NewDict(len(columns))
+		for i, c := range columns {
+			if err := row.SetKey(starlark.String(c), field(record[i])); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, row)
+	}
+	return starlark.NewList(rows), nil
+}
+
+var (
+	csvIntPattern   = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)$`)
+	csvFloatPattern = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+)
+
+// inferCSVValue converts a field to None, bool, int or float when it looks like one, and keeps it as a string otherwise.
+// Numbers with leading zeros, such as postal codes, stay strings.
+func inferCSVValue(v string) starlark.Value {
+	switch strings.ToLower(v) {
+	case "":
+		return starlark.None
+	case "true":
+		return starlark.True
+	case "false":
+		return starlark.False
+	}
+	if csvIntPattern.MatchString(v) {
+		i, _ := new(big.Int).SetString(v, 10)
+		return starlark.MakeBigInt(i)
+	}
+	if csvFloatPattern.MatchString(v) {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return starlark.Float(f)
+		}
+	}
+	return starlark.String(v)
+}
+
+// csvEncode renders rows as CSV. Rows may be dicts, in which case a header row is written, or lists.
+func csvEncode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rows *starlark.List
+	var columns *starlark.List
+	delimiter := ","
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rows", &rows, "columns?", &columns, "delimiter?", &delimiter); err != nil {
+		return nil, err
+	}
+	comma, err := csvDelimiter(delimiter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = comma
+
+	var header []string
+	if columns != nil {
+		for i := 0; i < columns.Len(); i++ {
+			c, ok := starlark.AsString(columns.Index(i))
+			if !ok {
+				return nil, fmt.Errorf("%s: columns must be strings, got %s", b.Name(), columns.Index(i).Type())
+			}
+			header = append(header, c)
+		}
+	} else {
+		// Default to the sorted union of the keys of all dict rows.
+		seen := map[string]bool{}
+		for i := 0; i < rows.Len(); i++ {
+			if row, ok := rows.Index(i).(*starlark.Dict); ok {
+				for _, k := range row.Keys() {
+					if ks, ok := k.(starlark.String); ok && !seen[string(ks)] {
+						seen[string(ks)] = true
+						header = append(header, string(ks))
+					}
+				}
+			}
+		}
+		sort.Strings(header)
+	}
+	if len(header) > 0 {
+		if err := w.Write(header); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+
+	for i := 0; i < rows.Len(); i++ {
+		var record []string
+		switch row := rows.Index(i).(type) {
+		case *starlark.Dict:
+			for _, c := range header {
+				v, found, err := row.Get(starlark.String(c))
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					v = starlark.None
+				}
+				s, err := csvField(v)
+				if err != nil {
+					return nil, fmt.Errorf("%s: row %d, column %q: %s", b.Name(), i, c, err)
+				}
+				record = append(record, s)
+			}
+		case starlark.Indexable:
+			if _, ok := row.(starlark.String); ok {
+				return nil, fmt.Errorf("%s: row %d: got string, want dict or list", b.Name(), i)
+			}
+			for j := 0; j < row.Len(); j++ {
+				s, err := csvField(row.Index(j))
+				if err != nil {
+					return nil, fmt.Errorf("%s: row %d, column %d: %s", b.Name(), i, j, err)
+				}
+				record = append(record, s)
+			}
+		default:
+			return nil, fmt.Errorf("%s: row %d: got %s, want dict or list", b.Name(), i, row.Type())
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(buf.String()), nil
+}
+
+func csvField(v starlark.Value) (string, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return "", nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bool:
+		return strconv.FormatBool(bool(v)), nil
+	case starlark.Int, starlark.Float:
+		return displayString(v), nil
+	default:
+		return "", fmt.Errorf("cannot encode %s as a CSV field", v.Type())
+	}
+}
+
+func csvDelimiter(delimiter string) (rune, error) {
+	r, size := utf8.DecodeRuneInString(delimiter)
+	if size == 0 || size != len(delimiter) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+	return r, nil
+}
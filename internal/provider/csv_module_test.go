@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCsvModule_parse(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "quoted" {
+					value = provider::starlark::eval(
+						"result = csv.parse(v)",
+						{ v = "name,description\nweb,\"frontend, public\"\n" }
+					)
+				}
+				output "inferred" {
+					value = provider::starlark::eval(
+						"result = csv.parse(v, delimiter = ';', infer_types = True)",
+						{ v = "name;count;enabled;zip\nweb;3;true;01234\n" }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("quoted", []interface{}{
+						map[string]interface{}{"name": "web", "description": "frontend, public"},
+					}),
+					NewTestCheckOutput("inferred", []interface{}{
+						map[string]interface{}{"name": "web", "count": json.Number("3"), "enabled": true, "zip": "01234"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCsvModule_encode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "dicts" {
+					value = provider::starlark::eval(
+						"result = csv.encode([{'name': 'web', 'description': 'frontend, public'}])",
+						{}
+					)
+				}
+				output "columns" {
+					value = provider::starlark::eval(
+						"result = csv.encode([{'name': 'web', 'count': 3}], columns = ['name', 'count'])",
+						{}
+					)
+				}
+				output "numbers" {
+					value = provider::starlark::eval("result = csv.encode(v, columns = ['name', 'port', 'limit'])", {
+						v = [{ name = "web", port = 8080, limit = 1000000 }]
+					})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("dicts", "description,name\n\"frontend, public\",web\n"),
+					resource.TestCheckOutput("columns", "name,count\nweb,3\n"),
+					resource.TestCheckOutput("numbers", "name,port,limit\nweb,8080,1000000\n"),
+				),
+			},
+		},
+	})
+}
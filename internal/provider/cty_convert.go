@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/zclconf/go-cty/cty"
+	"go.starlark.net/starlark"
+)
+
+// ctyToStarlark converts a cty value, as produced by HCL and Terraform's function library, to a Starlark value.
+func ctyToStarlark(val cty.Value) (starlark.Value, error) {
+	val, _ = val.Unmark()
+	if !val.IsKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+	if val.IsNull() {
+		return starlark.None, nil
+	}
+
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return starlark.String(val.AsString()), nil
+	case ty == cty.Bool:
+		return starlark.Bool(val.True()), nil
+	case ty == cty.Number:
+		bf := val.AsBigFloat()
+		if bf.IsInt() {
+			i, _ := bf.Int(nil)
+			return starlark.MakeBigInt(i), nil
+		}
+		f, _ := bf.Float64()
+		return starlark.Float(f), nil
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		elems := make([]starlark.Value, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			conv, err := ctyToStarlark(ev)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, conv)
+		}
+		return starlark.NewList(elems), nil
+	case ty.IsMapType() || ty.IsObjectType():
+		// ElementIterator visits keys in lexical order.
+		dict := starlark.NewDict(val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			conv, err := ctyToStarlark(ev)
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(k.AsString()), conv); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type: %s", ty.FriendlyName())
+	}
+}
+
+// starlarkToCty converts a Starlark value to a cty value. Lists become tuples and dicts become objects,
+// mirroring starlarkToTFValue.
+func starlarkToCty(val starlark.Value) (cty.Value, error) {
+	switch v := val.(type) {
+	case starlark.NoneType:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case starlark.String:
+		return cty.StringVal(string(v)), nil
+	case starlark.Bytes:
+		if !utf8.ValidString(string(v)) {
+			return cty.NilVal, fmt.Errorf("bytes value is not valid UTF-8 and cannot be converted to a string")
+		}
+		return cty.StringVal(string(v)), nil
+	case starlark.Bool:
+		return cty.BoolVal(bool(v)), nil
+	case starlark.Int:
+		return cty.NumberVal(new(big.Float).SetInt(v.BigInt())), nil
+	case starlark.Float:
+		if math.IsNaN(float64(v)) {
+			return cty.NilVal, fmt.Errorf("cannot convert NaN to a number")
+		}
+		return cty.NumberFloatVal(float64(v)), nil
+	case *decimalValue:
+		return cty.NumberVal(v.bigFloat()), nil
+	case starlark.IterableMapping:
+		attrs := make(map[string]cty.Value)
+		for _, item := range v.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return cty.NilVal, fmt.Errorf("%s keys must be strings, got %s", v.Type(), item[0].Type())
+			}
+			conv, err := starlarkToCty(item[1])
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[string(k)] = conv
+		}
+		return cty.ObjectVal(attrs), nil
+	case starlark.Indexable:
+		// e.g. list, tuple
+		elems := make([]cty.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			conv, err := starlarkToCty(v.Index(i))
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems = append(elems, conv)
+		}
+		return cty.TupleVal(elems), nil
+	case starlark.HasAttrs:
+		// e.g. struct
+		names := append([]string(nil), v.AttrNames()...)
+		sort.Strings(names)
+		attrs := make(map[string]cty.Value)
+		for _, name := range names {
+			av, err := v.Attr(name)
+			if err != nil || av == nil {
+				return cty.NilVal, fmt.Errorf("cannot access attribute %s.%s", v.Type(), name)
+			}
+			conv, err := starlarkToCty(av)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[name] = conv
+		}
+		return cty.ObjectVal(attrs), nil
+	default:
+		return cty.NilVal, fmt.Errorf("cannot convert %s to an HCL value", v.Type())
+	}
+}
@@ -0,0 +1,526 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// decimalModule implements the "decimal" module with exact decimal arithmetic.
+var decimalModule = &starlarkstruct.Module{
+	Name: "decimal",
+	Members: starlark.StringDict{
+		"parse":    starlark.NewBuiltin("decimal.parse", decimalParse),
+		"quantize": starlark.NewBuiltin("decimal.quantize", decimalQuantize),
+		"div":      starlark.NewBuiltin("decimal.div", decimalDiv),
+		"sum":      starlark.NewBuiltin("decimal.sum", decimalSum),
+		"allocate": starlark.NewBuiltin("decimal.allocate", decimalAllocate),
+		"format":   starlark.NewBuiltin("decimal.format", decimalFormat),
+	},
+}
+
+const (
+	// decimalPrecision is the number of significant digits of an inexact quotient, as in Python.
+	decimalPrecision = 28
+	// decimalMaxScale bounds exponents and decimal places so that a typo cannot allocate huge numbers.
+	decimalMaxScale = 1000
+)
+
+// decimalNumberPrecision is the big.Float precision Terraform uses when it parses numbers.
+const decimalNumberPrecision = 512
+
+var decimalPattern = regexp.MustCompile(`^([+-]?)(\d*)(?:\.(\d*))?(?:[eE]([+-]?\d+))?$`)
+
+// decimalValue is the Starlark decimal type: unscaled * 10^-scale, with scale >= 0. Like Python's
+// Decimal, the scale is kept, so "1.50" and "1.5" are equal but print differently.
+type decimalValue struct {
+	unscaled *big.Int
+	scale    int
+}
+
+var (
+	_ starlark.Value          = (*decimalValue)(nil)
+	_ starlark.HasBinary      = (*decimalValue)(nil)
+	_ starlark.HasUnary       = (*decimalValue)(nil)
+	_ starlark.TotallyOrdered = (*decimalValue)(nil)
+)
+
+func (d *decimalValue) String() string {
+	digits := new(big.Int).Abs(d.unscaled).String()
+	sign := ""
+	if d.unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (d *decimalValue) Type() string         { return "decimal" }
+func (d *decimalValue) Freeze()              {}
+func (d *decimalValue) Truth() starlark.Bool { return d.unscaled.Sign() != 0 }
+
+func (d *decimalValue) Hash() (uint32, error) {
+	return starlark.String(d.normalize().String()).Hash()
+}
+
+func (d *decimalValue) Cmp(y starlark.Value, _ int) (int, error) {
+	a, b := alignDecimals(d, y.(*decimalValue))
+	return a.Cmp(b), nil
+}
+
+func (d *decimalValue) Unary(op syntax.Token) (starlark.Value, error) {
+	switch op {
+	case syntax.MINUS:
+		return &decimalValue{new(big.Int).Neg(d.unscaled), d.scale}, nil
+	case syntax.PLUS:
+		return d, nil
+	}
+	return nil, nil
+}
+
+// Binary implements arithmetic between decimals, and between decimals and ints. Floats are not
+// accepted, since mixing them in would lose the exactness decimals are for.
+func (d *decimalValue) Binary(op syntax.Token, y starlark.Value, side starlark.Side) (starlark.Value, error) {
+	var other *decimalValue
+	switch y := y.(type) {
+	case *decimalValue:
+		other = y
+	case starlark.Int:
+		other = &decimalValue{y.BigInt(), 0}
+	default:
+		return nil, nil
+	}
+	x := d
+	if side == starlark.Right {
+		x, other = other, x
+	}
+	switch op {
+	case syntax.PLUS:
+		a, b := alignDecimals(x, other)
+		return &decimalValue{a.Add(a, b), max(x.scale, other.scale)}, nil
+	case syntax.MINUS:
+		a, b := alignDecimals(x, other)
+		return &decimalValue{a.Sub(a, b), max(x.scale, other.scale)}, nil
+	case syntax.STAR:
+		return &decimalValue{new(big.Int).Mul(x.unscaled, other.unscaled), x.scale + other.scale}, nil
+	case syntax.SLASH:
+		return x.quo(other)
+	case syntax.SLASHSLASH, syntax.PERCENT:
+		if other.unscaled.Sign() == 0 {
+			return nil, fmt.Errorf("decimal division by zero")
+		}
+		// Like Python's decimal, // truncates toward zero and % has the sign of the dividend.
+		a, b := alignDecimals(x, other)
+		q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+		if op == syntax.SLASHSLASH {
+			return &decimalValue{q, 0}, nil
+		}
+		return &decimalValue{r, max(x.scale, other.scale)}, nil
+	}
+	return nil, nil
+}
+
+// alignDecimals returns the unscaled values of a and b at their common scale.
+func alignDecimals(a, b *decimalValue) (*big.Int, *big.Int) {
+	scale := max(a.scale, b.scale)
+	return a.rescaled(scale), b.rescaled(scale)
+}
+
+// rescaled returns the unscaled value of d at a scale that is not smaller than d.scale.
+func (d *decimalValue) rescaled(scale int) *big.Int {
+	return new(big.Int).Mul(d.unscaled, pow10(scale-d.scale))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// normalize removes trailing zeros after the decimal point.
+func (d *decimalValue) normalize() *decimalValue {
+	return d.trimZeros(0)
+}
+
+// trimZeros removes trailing zeros after the decimal point, keeping at least minScale places.
+func (d *decimalValue) trimZeros(minScale int) *decimalValue {
+	unscaled, scale := new(big.Int).Set(d.unscaled), d.scale
+	ten, r := big.NewInt(10), new(big.Int)
+	for scale > minScale {
+		q, _ := new(big.Int).QuoRem(unscaled, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		unscaled, scale = q, scale-1
+	}
+	return &decimalValue{unscaled, scale}
+}
+
+// quo divides d by y. An exact quotient keeps the scale of d minus the scale of y, as far as
+// possible; otherwise it is rounded half-even to 28 significant digits.
+func (d *decimalValue) quo(y *decimalValue) (*decimalValue, error) {
+	if y.unscaled.Sign() == 0 {
+		return nil, fmt.Errorf("decimal division by zero")
+	}
+	num := new(big.Int).Mul(d.unscaled, pow10(y.scale))
+	den := new(big.Int).Mul(y.unscaled, pow10(d.scale))
+	// The leading digit of the quotient is at 10^lead.
+	absNum, absDen := new(big.Int).Abs(num), new(big.Int).Abs(den)
+	lead := len(absNum.String()) - len(absDen.String())
+	if lead >= 0 && absNum.Cmp(absDen.Mul(absDen, pow10(lead))) < 0 || lead < 0 && absNum.Mul(absNum, pow10(-lead)).Cmp(absDen) < 0 {
+		lead--
+	}
+	scale := max(decimalPrecision-lead-1, 0)
+	q, exact := roundQuo(new(big.Int).Mul(num, pow10(scale)), den, "half_even")
+	result := &decimalValue{q, scale}
+	if exact {
+		return result.trimZeros(min(max(d.scale-y.scale, 0), scale)), nil
+	}
+	return result, nil
+}
+
+// decimalRoundingModes are the rounding modes of Python's decimal module, without the ROUND_ prefix.
+var decimalRoundingModes = []string{"half_even", "half_up", "half_down", "up", "down", "ceiling", "floor"}
+
+func checkRoundingMode(mode string) error {
+	for _, m := range decimalRoundingModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown rounding mode %q, want one of %s", mode, strings.Join(decimalRoundingModes, ", "))
+}
+
+// roundQuo returns num / den rounded to an integer with the given mode, and whether it was exact.
+func roundQuo(num, den *big.Int, mode string) (*big.Int, bool) {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q, true
+	}
+	sign := num.Sign() * den.Sign()
+	var away bool
+	switch mode {
+	case "up":
+		away = true
+	case "down":
+		away = false
+	case "ceiling":
+		away = sign > 0
+	case "floor":
+		away = sign < 0
+	default:
+		half := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).CmpAbs(den)
+		switch {
+		case half > 0:
+			away = true
+		case half < 0:
+			away = false
+		case mode == "half_up":
+			away = true
+		case mode == "half_down":
+			away = false
+		default:
+			away = q.Bit(0) == 1
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q, false
+}
+
+// quantize rounds d to places decimal places. Negative places round to tens, hundreds and so on.
+func (d *decimalValue) quantize(places int, mode string) *decimalValue {
+	if places >= d.scale {
+		return &decimalValue{d.rescaled(places), places}
+	}
+	q, _ := roundQuo(d.unscaled, pow10(d.scale-places), mode)
+	if places < 0 {
+		return &decimalValue{q.Mul(q, pow10(-places)), 0}
+	}
+	return &decimalValue{q, places}
+}
+
+func parseDecimal(s string) (*decimalValue, error) {
+	m := decimalPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || m[2]+m[3] == "" {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	exp := 0
+	if m[4] != "" {
+		e, err := strconv.Atoi(m[4])
+		if err != nil || e > decimalMaxScale || e < -decimalMaxScale {
+			return nil, fmt.Errorf("exponent of %q out of range", s)
+		}
+		exp = e
+	}
+	unscaled, _ := new(big.Int).SetString(m[2]+m[3], 10)
+	if m[1] == "-" {
+		unscaled.Neg(unscaled)
+	}
+	scale := len(m[3]) - exp
+	if scale < 0 {
+		return &decimalValue{unscaled.Mul(unscaled, pow10(-scale)), 0}, nil
+	}
+	if scale > decimalMaxScale {
+		return nil, fmt.Errorf("%q has more than %d decimal places", s, decimalMaxScale)
+	}
+	return &decimalValue{unscaled, scale}, nil
+}
+
+// toDecimal converts a decimal, int, string or float to a decimal. A float is converted through
+// its shortest string form, so 0.1 becomes exactly 0.1.
+func toDecimal(v starlark.Value) (*decimalValue, error) {
+	switch v := v.(type) {
+	case *decimalValue:
+		return v, nil
+	case starlark.Int:
+		return &decimalValue{v.BigInt(), 0}, nil
+	case starlark.String:
+		return parseDecimal(string(v))
+	case starlark.Float:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, fmt.Errorf("cannot convert %s to a decimal", v)
+		}
+		return parseDecimal(strconv.FormatFloat(float64(v), 'g', -1, 64))
+	}
+	return nil, fmt.Errorf("got %s, want decimal, int, float or string", v.Type())
+}
+
+// bigFloat converts d to a big.Float the way Terraform parses the same number from configuration.
+func (d *decimalValue) bigFloat() *big.Float {
+	f, _, _ := big.ParseFloat(d.String(), 10, decimalNumberPrecision, big.ToNearestEven)
+	return f
+}
+
+func checkPlaces(places int) error {
+	if places > decimalMaxScale || places < -decimalMaxScale {
+		return fmt.Errorf("places must be between %d and %d", -decimalMaxScale, decimalMaxScale)
+	}
+	return nil
+}
+
+func decimalParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "x", &x); err != nil {
+		return nil, err
+	}
+	d, err := toDecimal(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return d, nil
+}
+
+func decimalQuantize(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	var places int
+	rounding := "half_even"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "x", &x, "places", &places, "rounding?", &rounding); err != nil {
+		return nil, err
+	}
+	d, err := toDecimal(x)
+	if err == nil {
+		err = checkPlaces(places)
+	}
+	if err == nil {
+		err = checkRoundingMode(rounding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return d.quantize(places, rounding), nil
+}
+
+// decimalDiv divides x by y and rounds the quotient to places decimal places.
+func decimalDiv(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y starlark.Value
+	var places int
+	rounding := "half_even"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "x", &x, "y", &y, "places", &places, "rounding?", &rounding); err != nil {
+		return nil, err
+	}
+	a, err := toDecimal(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: x: %s", b.Name(), err)
+	}
+	d, err := toDecimal(y)
+	if err != nil {
+		return nil, fmt.Errorf("%s: y: %s", b.Name(), err)
+	}
+	if err := checkPlaces(places); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if err := checkRoundingMode(rounding); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if d.unscaled.Sign() == 0 {
+		return nil, fmt.Errorf("%s: division by zero", b.Name())
+	}
+	// x / y * 10^places = (ax * 10^-sx) / (ay * 10^-sy) * 10^places
+	num := new(big.Int).Mul(a.unscaled, pow10(d.scale+max(places, 0)))
+	den := new(big.Int).Mul(d.unscaled, pow10(a.scale+max(-places, 0)))
+	q, _ := roundQuo(num, den, rounding)
+	if places < 0 {
+		return &decimalValue{q.Mul(q, pow10(-places)), 0}, nil
+	}
+	return &decimalValue{q, places}, nil
+}
+
+func decimalSum(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var values starlark.Iterable
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "values", &values); err != nil {
+		return nil, err
+	}
+	sum := &decimalValue{new(big.Int), 0}
+	iter := values.Iterate()
+	defer iter.Done()
+	var v starlark.Value
+	for i := 0; iter.Next(&v); i++ {
+		d, err := toDecimal(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: element %d: %s", b.Name(), i, err)
+		}
+		a, c := alignDecimals(sum, d)
+		sum = &decimalValue{a.Add(a, c), max(sum.scale, d.scale)}
+	}
+	return sum, nil
+}
+
+// decimalAllocate splits total into parts proportional to weights, each rounded to places decimal
+// places, so that the parts add up to exactly total. The units left over by rounding down go to the
+// parts with the largest remainders, and to earlier parts on ties.
+func decimalAllocate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	var weightsValue starlark.Iterable
+	places := 2
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "total", &x, "weights", &weightsValue, "places?", &places); err != nil {
+		return nil, err
+	}
+	total, err := toDecimal(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: total: %s", b.Name(), err)
+	}
+	if places < 0 || places > decimalMaxScale {
+		return nil, fmt.Errorf("%s: places must be between 0 and %d", b.Name(), decimalMaxScale)
+	}
+	if total.scale > places && total.normalize().scale > places {
+		return nil, fmt.Errorf("%s: total %s has more than %d decimal places", b.Name(), total, places)
+	}
+	// Work in units of 10^-places.
+	units := total.quantize(places, "down").unscaled
+	values, err := iterableValues(weightsValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s: weights must not be empty", b.Name())
+	}
+
+	weights := make([]*decimalValue, len(values))
+	weightSum := &decimalValue{new(big.Int), 0}
+	for i, v := range values {
+		w, err := toDecimal(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: weight %d: %s", b.Name(), i, err)
+		}
+		if w.unscaled.Sign() < 0 {
+			return nil, fmt.Errorf("%s: weight %d is negative", b.Name(), i)
+		}
+		weights[i] = w
+		a, c := alignDecimals(weightSum, w)
+		weightSum = &decimalValue{a.Add(a, c), max(weightSum.scale, w.scale)}
+	}
+	if weightSum.unscaled.Sign() == 0 {
+		return nil, fmt.Errorf("%s: weights must not all be zero", b.Name())
+	}
+
+	// part_i = units * w_i / sum(w), truncated toward zero.
+	scale := weightSum.scale
+	den := weightSum.unscaled
+	parts := make([]*big.Int, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	allocated := new(big.Int)
+	for i, w := range weights {
+		num := new(big.Int).Mul(units, w.rescaled(scale))
+		parts[i], remainders[i] = new(big.Int).QuoRem(num, den, new(big.Int))
+		remainders[i].Abs(remainders[i])
+		allocated.Add(allocated, parts[i])
+	}
+	order := make([]int, len(parts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, c int) bool {
+		return remainders[order[a]].Cmp(remainders[order[c]]) > 0
+	})
+	left := new(big.Int).Sub(units, allocated)
+	step := big.NewInt(int64(left.Sign()))
+	for i := 0; left.Sign() != 0; i++ {
+		parts[order[i]].Add(parts[order[i]], step)
+		left.Sub(left, step)
+	}
+
+	result := make([]starlark.Value, len(parts))
+	for i, p := range parts {
+		result[i] = &decimalValue{p, places}
+	}
+	return starlark.NewList(result), nil
+}
+
+// decimalFormat formats x with a fixed number of decimal places and an optional thousands separator.
+func decimalFormat(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	places := -1
+	thousandsSep := ""
+	rounding := "half_even"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "x", &x, "places?", &places, "thousands_sep?", &thousandsSep, "rounding?", &rounding); err != nil {
+		return nil, err
+	}
+	d, err := toDecimal(x)
+	if err == nil {
+		err = checkRoundingMode(rounding)
+	}
+	if err == nil && places > decimalMaxScale {
+		err = fmt.Errorf("places must not be more than %d", decimalMaxScale)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if places >= 0 {
+		d = d.quantize(places, rounding)
+	}
+	s := d.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	if thousandsSep != "" {
+		var groups []string
+		for len(integer) > 3 {
+			groups = append([]string{integer[len(integer)-3:]}, groups...)
+			integer = integer[:len(integer)-3]
+		}
+		integer = strings.Join(append([]string{integer}, groups...), thousandsSep)
+	}
+	if hasFraction {
+		return starlark.String(sign + integer + "." + fraction), nil
+	}
+	return starlark.String(sign + integer), nil
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDecimalModule_arithmetic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "sum" {
+					value = provider::starlark::eval("result = decimal.parse('0.1') + decimal.parse('0.2')", {})
+				}
+				output "from_floats" {
+					value = provider::starlark::eval("result = decimal.parse(a) + decimal.parse(b) == decimal.parse('0.3')", {
+						a = 0.1
+						b = 0.2
+					})
+				}
+				output "ops" {
+					value = provider::starlark::eval(<<-EOT
+					d = decimal.parse
+					result = [str(x) for x in [d('1') / 3, d('1.00') / 4, 2 - d('0.75'), d('1.5') * 3, d('-7') // 2, d('7.5') % 2, -d('1e3')]]
+					EOT
+					, {})
+				}
+				output "sorted" {
+					value = provider::starlark::eval("result = [str(x) for x in sorted([decimal.parse(x) for x in ['10', '9.5', '-1', '0.001']])]", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("sum", "0.3"),
+					resource.TestCheckOutput("from_floats", "true"),
+					NewTestCheckOutput("ops", []interface{}{"0.3333333333333333333333333333", "0.25", "1.25", "4.5", "-3", "1.5", "-1000"}),
+					NewTestCheckOutput("sorted", []interface{}{"-1", "0.001", "9.5", "10"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDecimalModule_rounding(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "modes" {
+					value = provider::starlark::eval(<<-EOT
+					modes = ['half_even', 'half_up', 'half_down', 'up', 'down', 'ceiling', 'floor']
+					result = {m: [str(decimal.quantize(x, 0, m)) for x in ['2.5', '-2.5']] for m in modes}
+					EOT
+					, {})
+				}
+				output "quantize" {
+					value = provider::starlark::eval("result = [str(decimal.quantize('1234.5678', p)) for p in [2, -2]] + [str(decimal.quantize('1.2', 3))]", {})
+				}
+				output "div" {
+					value = provider::starlark::eval("result = [str(decimal.div(10, 3, 2)), str(decimal.div('-10', 3, 2, rounding = 'floor'))]", {})
+				}
+				output "format" {
+					value = provider::starlark::eval("result = [decimal.format('1234567.891', 2, thousands_sep = ','), decimal.format('999', 2, thousands_sep = ',')]", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("modes", map[string]interface{}{
+						"half_even": []interface{}{"2", "-2"},
+						"half_up":   []interface{}{"3", "-3"},
+						"half_down": []interface{}{"2", "-2"},
+						"up":        []interface{}{"3", "-3"},
+						"down":      []interface{}{"2", "-2"},
+						"ceiling":   []interface{}{"3", "-2"},
+						"floor":     []interface{}{"2", "-3"},
+					}),
+					NewTestCheckOutput("quantize", []interface{}{"1234.57", "1200", "1.200"}),
+					NewTestCheckOutput("div", []interface{}{"3.33", "-3.34"}),
+					NewTestCheckOutput("format", []interface{}{"1,234,567.89", "999.00"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDecimalModule_allocate(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "thirds" {
+					value = provider::starlark::eval("result = decimal.allocate('100.00', [1, 1, 1])", {})
+				}
+				output "negative" {
+					value = provider::starlark::eval("result = decimal.allocate('-10', [1, 2])", {})
+				}
+				output "sum" {
+					value = provider::starlark::eval("result = decimal.sum(['0.1', 0.2, 1, decimal.parse('0.005')])", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("thirds", []interface{}{json.Number("33.34"), json.Number("33.33"), json.Number("33.33")}),
+					NewTestCheckOutput("negative", []interface{}{json.Number("-3.33"), json.Number("-6.67")}),
+					resource.TestCheckOutput("sum", "1.305"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDecimalModule_floatOperand(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = decimal.parse('1') + 0.5", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`decimal \+ float`),
+			},
+		},
+	})
+}
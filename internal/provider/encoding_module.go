@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// encodingModule implements the "encoding" module.
+//
+// Encoders take a string or bytes and return a string. Decoders return a string,
+// or bytes when called with as_bytes=True.
+var encodingModule = &starlarkstruct.Module{
+	Name: "encoding",
+	Members: starlark.StringDict{
+		"base64_encode": starlark.NewBuiltin("encoding.base64_encode", encodingBase64Encode),
+		"base64_decode": starlark.NewBuiltin("encoding.base64_decode", encodingBase64Decode),
+		"base32_encode": starlark.NewBuiltin("encoding.base32_encode", encodingBase32Encode),
+		"base32_decode": starlark.NewBuiltin("encoding.base32_decode", encodingBase32Decode),
+		"hex_encode":    starlark.NewBuiltin("encoding.hex_encode", encodingHexEncode),
+		"hex_decode":    starlark.NewBuiltin("encoding.hex_decode", encodingHexDecode),
+	},
+}
+
+func base64Encoding(url, padding bool) *base64.Encoding {
+	enc := base64.StdEncoding
+	if url {
+		enc = base64.URLEncoding
+	}
+	if !padding {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	return enc
+}
+
+func encodingBase64Encode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	url := false
+	padding := true
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data, "url?", &url, "padding?", &padding); err != nil {
+		return nil, err
+	}
+	return starlark.String(base64Encoding(url, padding).EncodeToString(data)), nil
+}
+
+func encodingBase64Decode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	url := false
+	asBytes := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "url?", &url, "as_bytes?", &asBytes); err != nil {
+		return nil, err
+	}
+	// Accept both padded and unpadded input.
+	data, err := base64Encoding(url, false).DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return decodedValue(data, asBytes), nil
+}
+
+func encodingBase32Encode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	padding := true
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data, "padding?", &padding); err != nil {
+		return nil, err
+	}
+	enc := base32.StdEncoding
+	if !padding {
+		enc = enc.WithPadding(base32.NoPadding)
+	}
+	return starlark.String(enc.EncodeToString(data)), nil
+}
+
+func encodingBase32Decode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	asBytes := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "as_bytes?", &asBytes); err != nil {
+		return nil, err
+	}
+	data, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(strings.ToUpper(s), "="))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return decodedValue(data, asBytes), nil
+}
+
+func encodingHexEncode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data); err != nil {
+		return nil, err
+	}
+	return starlark.String(hex.EncodeToString(data)), nil
+}
+
+func encodingHexDecode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	asBytes := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "as_bytes?", &asBytes); err != nil {
+		return nil, err
+	}
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return decodedValue(data, asBytes), nil
+}
+
+func decodedValue(data []byte, asBytes bool) starlark.Value {
+	if asBytes {
+		return starlark.Bytes(data)
+	}
+	return starlark.String(data)
+}
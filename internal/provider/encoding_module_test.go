@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEncodingModule_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "base64" {
+					value = provider::starlark::eval("result = encoding.base64_encode(v)", { v = "hi?>" })
+				}
+				output "base64_url" {
+					value = provider::starlark::eval("result = encoding.base64_encode(v, url = True, padding = False)", { v = "hi?>" })
+				}
+				output "base64_decode" {
+					value = provider::starlark::eval("result = encoding.base64_decode(v, url = True)", { v = "aGk_Pg" })
+				}
+				output "base32" {
+					value = provider::starlark::eval("result = encoding.base32_encode(v)", { v = "hello" })
+				}
+				output "base32_decode" {
+					value = provider::starlark::eval("result = encoding.base32_decode(v)", { v = "NBSWY3DP" })
+				}
+				output "hex_round_trip" {
+					value = provider::starlark::eval("result = encoding.hex_decode(encoding.hex_encode(v))", { v = "hello" })
+				}
+				output "digest_bytes" {
+					value = provider::starlark::eval("result = encoding.hex_encode(hash.sha256(v, encoding = 'bytes')) == hash.sha256(v)", { v = "hello" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("base64", "aGk/Pg=="),
+					resource.TestCheckOutput("base64_url", "aGk_Pg"),
+					resource.TestCheckOutput("base64_decode", "hi?>"),
+					resource.TestCheckOutput("base32", "NBSWY3DP"),
+					resource.TestCheckOutput("base32_decode", "hello"),
+					resource.TestCheckOutput("hex_round_trip", "hello"),
+					resource.TestCheckOutput("digest_bytes", "true"),
+				),
+			},
+		},
+	})
+}
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"unicode/utf8"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
 	"go.starlark.net/syntax"
 )
 
@@ -64,31 +66,10 @@ func (f Eval) Run(ctx context.Context, req function.RunRequest, resp *function.R
 		Print: func(_ *starlark.Thread, msg string) { fmt.Println(msg) }, // Optional: wire up to TF logs?
 	}
 
-	// Convert inputs to Starlark types
-	globals := starlark.StringDict{}
-
-	if !inputs.IsNull() && !inputs.IsUnknown() {
-		val, err := attrValueToStarlark(ctx, inputs)
-		if err != nil {
-			resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert inputs: %s", err))
-			return
-		}
-
-		dict, ok := val.(*starlark.Dict)
-		if !ok {
-			resp.Error = function.NewFuncError(fmt.Sprintf("inputs must be a map or object, got %s", val.Type()))
-			return
-		}
-
-		for _, item := range dict.Items() {
-			k, ok := item[0].(starlark.String)
-			if !ok {
-				// Should have been checked upstream or during conversion if we enforce string keys
-				resp.Error = function.NewFuncError(fmt.Sprintf("input keys must be strings, got %s", item[0].Type()))
-				return
-			}
-			globals[string(k)] = item[1]
-		}
+	globals, err := inputGlobals(ctx, inputs)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
 	}
 
 	// Execute Starlark script
@@ -99,7 +80,7 @@ func (f Eval) Run(ctx context.Context, req function.RunRequest, resp *function.R
 	// As a fallback/alternative, if the script is a single expression, Eval calls might be appropriate, but scripts are usually multiple lines.
 	// We will look for a global variable named "result".
 
-	scriptGlobals, err := starlark.ExecFileOptions(&syntax.FileOptions{Recursion: true, While: true}, thread, "script.star", script, globals)
+	scriptGlobals, err := starlark.ExecFileOptions(scriptOptions, thread, "script.star", script, globals)
 	if err != nil {
 		resp.Error = function.NewFuncError(fmt.Sprintf("starlark execution failed: %s", err))
 		return
@@ -127,6 +108,36 @@ func (f Eval) Run(ctx context.Context, req function.RunRequest, resp *function.R
 	resp.Error = resp.Result.Set(ctx, tfVal)
 }
 
+// scriptOptions enables the language features that scripts and templates may use.
+var scriptOptions = &syntax.FileOptions{Recursion: true, While: true}
+
+// inputGlobals converts the inputs to Starlark types and layers them on top of the built-in modules.
+func inputGlobals(ctx context.Context, inputs types.Dynamic) (starlark.StringDict, error) {
+	globals := predeclaredModules()
+	if inputs.IsNull() || inputs.IsUnknown() {
+		return globals, nil
+	}
+
+	val, err := attrValueToStarlark(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert inputs: %s", err)
+	}
+
+	dict, ok := val.(*starlark.Dict)
+	if !ok {
+		return nil, fmt.Errorf("inputs must be a map or object, got %s", val.Type())
+	}
+
+	for _, item := range dict.Items() {
+		k, ok := item[0].(starlark.String)
+		if !ok {
+			return nil, fmt.Errorf("input keys must be strings, got %s", item[0].Type())
+		}
+		globals[string(k)] = item[1]
+	}
+	return globals, nil
+}
+
 // attrValueToStarlark converts a Terraform attr.Value (including Dynamic) to a Starlark value.
 func attrValueToStarlark(ctx context.Context, val attr.Value) (starlark.Value, error) {
 	if val.IsNull() || val.IsUnknown() {
@@ -201,6 +212,11 @@ func starlarkToTFValue(ctx context.Context, val starlark.Value) (attr.Value, err
 		return types.DynamicNull(), nil
 	case starlark.String:
 		return types.StringValue(string(v)), nil
+	case starlark.Bytes:
+		if !utf8.ValidString(string(v)) {
+			return nil, fmt.Errorf("bytes value is not valid UTF-8 and cannot be converted to a string")
+		}
+		return types.StringValue(string(v)), nil
 	case starlark.Bool:
 		return types.BoolValue(bool(v)), nil
 	case starlark.Int:
@@ -213,6 +229,8 @@ func starlarkToTFValue(ctx context.Context, val starlark.Value) (attr.Value, err
 		return types.NumberValue(new(big.Float).SetInt(v.BigInt())), nil
 	case starlark.Float:
 		return types.Float64Value(float64(v)), nil
+	case *decimalValue:
+		return types.NumberValue(v.bigFloat()), nil
 	case *starlark.List:
 		// Convert list to TupleValue for flexibility with varied types
 		n := v.Len()
@@ -262,6 +280,31 @@ func starlarkToTFValue(ctx context.Context, val starlark.Value) (attr.Value, err
 		}
 		return objVal, nil
 
+	case *starlarkstruct.Struct:
+		// Structs, such as the result of semver.parse, are converted to objects as well
+		attrTypes := make(map[string]attr.Type)
+		attrValues := make(map[string]attr.Value)
+
+		for _, name := range v.AttrNames() {
+			val, err := v.Attr(name)
+			if err != nil {
+				return nil, err
+			}
+			tfVal, err := starlarkToTFValue(ctx, val)
+			if err != nil {
+				return nil, err
+			}
+
+			attrTypes[name] = tfVal.Type(ctx)
+			attrValues[name] = tfVal
+		}
+
+		objVal, diags := types.ObjectValue(attrTypes, attrValues)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to create object: %s", diags)
+		}
+		return objVal, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported starlark return type: %s", v.Type())
 	}
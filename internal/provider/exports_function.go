@@ -0,0 +1,206 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.starlark.net/starlark"
+)
+
+// Ensure the implementation satisfies the interface.
+var _ function.Function = Exports{}
+
+func NewExportsFunction() function.Function {
+	return Exports{}
+}
+
+// Exports implements the "exports" function.
+type Exports struct{}
+
+func (f Exports) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "exports"
+}
+
+func (f Exports) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Execute a Starlark script and return its globals",
+		Description: "Executes the provided Starlark script with the given inputs and returns an object of every public global it defines, except functions and inputs.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "script",
+				Description: "The Starlark source code to execute.",
+			},
+			function.DynamicParameter{
+				Name:        "inputs",
+				Description: "A map of variables to inject into the Starlark global scope.",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:        "options",
+			Description: "An optional object with \"names\", a list of the globals to return, and \"skip_unsupported\", whether to leave out values that cannot be converted instead of failing.",
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+// exportsOptions are the options of the "exports" function.
+type exportsOptions struct {
+	names           []string
+	skipUnsupported bool
+}
+
+func (f Exports) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var script string
+	var inputs types.Dynamic
+	var options []types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &script, &inputs, &options)
+	if resp.Error != nil {
+		return
+	}
+
+	opts, err := parseExportsOptions(ctx, options)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	thread := &starlark.Thread{
+		Name:  "terraform-provider-starlark-exports",
+		Print: func(_ *starlark.Thread, msg string) { fmt.Println(msg) },
+	}
+
+	globals, err := inputGlobals(ctx, inputs)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	scriptGlobals, err := starlark.ExecFileOptions(scriptOptions, thread, "script.star", script, globals)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("starlark execution failed: %s", err))
+		return
+	}
+
+	names := opts.names
+	if names == nil {
+		// Inputs are predeclared, so they only appear here when the script assigns them again.
+		for name := range scriptGlobals {
+			if strings.HasPrefix(name, "_") {
+				continue
+			}
+			if isInputName(inputs, name) {
+				continue
+			}
+			if _, ok := scriptGlobals[name].(starlark.Callable); ok {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	attrTypes := make(map[string]attr.Type, len(names))
+	attrValues := make(map[string]attr.Value, len(names))
+	for _, name := range names {
+		val, ok := scriptGlobals[name]
+		if !ok {
+			resp.Error = function.NewFuncError(fmt.Sprintf("%q is not a global defined by the script", name))
+			return
+		}
+		tfVal, err := starlarkToTFValue(ctx, val)
+		if err != nil {
+			if opts.skipUnsupported {
+				continue
+			}
+			resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert %s: %s", name, err))
+			return
+		}
+		attrTypes[name] = tfVal.Type(ctx)
+		attrValues[name] = tfVal
+	}
+
+	objVal, diags := types.ObjectValue(attrTypes, attrValues)
+	if diags.HasError() {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to create object: %s", diags))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(objVal))
+}
+
+// isInputName reports whether name is a key of the inputs.
+func isInputName(inputs types.Dynamic, name string) bool {
+	switch v := inputs.UnderlyingValue().(type) {
+	case types.Object:
+		_, ok := v.Attributes()[name]
+		return ok
+	case types.Map:
+		_, ok := v.Elements()[name]
+		return ok
+	}
+	return false
+}
+
+// parseExportsOptions reads the optional options object of the "exports" function.
+func parseExportsOptions(ctx context.Context, options []types.Dynamic) (exportsOptions, error) {
+	var opts exportsOptions
+	if len(options) == 0 {
+		return opts, nil
+	}
+	if len(options) > 1 {
+		return opts, fmt.Errorf("expected at most one options object, got %d", len(options))
+	}
+
+	val, err := attrValueToStarlark(ctx, options[0])
+	if err != nil {
+		return opts, fmt.Errorf("failed to convert options: %s", err)
+	}
+	if val == starlark.None {
+		return opts, nil
+	}
+	dict, ok := val.(*starlark.Dict)
+	if !ok {
+		return opts, fmt.Errorf("options must be an object, got %s", val.Type())
+	}
+
+	for _, item := range dict.Items() {
+		switch key := string(item[0].(starlark.String)); key {
+		case "names":
+			if item[1] == starlark.None {
+				continue
+			}
+			list, ok := item[1].(*starlark.List)
+			if !ok {
+				return opts, fmt.Errorf("options.names must be a list of strings, got %s", item[1].Type())
+			}
+			opts.names = make([]string, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				s, ok := list.Index(i).(starlark.String)
+				if !ok {
+					return opts, fmt.Errorf("options.names must be a list of strings, got %s at index %d", list.Index(i).Type(), i)
+				}
+				opts.names = append(opts.names, string(s))
+			}
+		case "skip_unsupported":
+			if item[1] == starlark.None {
+				continue
+			}
+			b, ok := item[1].(starlark.Bool)
+			if !ok {
+				return opts, fmt.Errorf("options.skip_unsupported must be a bool, got %s", item[1].Type())
+			}
+			opts.skipUnsupported = bool(b)
+		default:
+			return opts, fmt.Errorf("unknown option %q, expected names or skip_unsupported", key)
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccExportsFunction_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					network = provider::starlark::exports(
+						<<-EOT
+						_base = "10.0.%d.0/24"
+
+						def subnet(i):
+							return _base % i
+
+						subnets = [subnet(i) for i in range(int(count))]
+						routes = {"default": gateway}
+						enabled = True
+						EOT
+						,
+						{
+							count   = 2
+							gateway = "10.0.0.1"
+						}
+					)
+				}
+				output "subnets" {
+					value = local.network.subnets
+				}
+				output "routes" {
+					value = local.network.routes
+				}
+				output "names" {
+					value = keys(local.network)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("subnets", []interface{}{"10.0.0.0/24", "10.0.1.0/24"}),
+					NewTestCheckOutput("routes", map[string]interface{}{"default": "10.0.0.1"}),
+					NewTestCheckOutput("names", []interface{}{"enabled", "routes", "subnets"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExportsFunction_options(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "names" {
+					value = provider::starlark::exports("a = 1\nb = 2\nc = 3", {}, { names = ["a", "c"] })
+				}
+				output "skip" {
+					value = provider::starlark::exports("a = 1\nh = hash", {}, { skip_unsupported = true })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("names", map[string]interface{}{"a": json.Number("1"), "c": json.Number("3")}),
+					NewTestCheckOutput("skip", map[string]interface{}{"a": json.Number("1")}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExportsFunction_unsupported(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::exports("a = 1\nh = hash", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`failed to convert h`),
+			},
+		},
+	})
+}
+
+func TestAccExportsFunction_unknownName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::exports("a = 1", {}, { names = ["missing"] })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`"missing" is not a global`),
+			},
+		},
+	})
+}
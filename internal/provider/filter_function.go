@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.starlark.net/starlark"
+)
+
+// Ensure the implementation satisfies the interface.
+var _ function.Function = Filter{}
+
+func NewFilterFunction() function.Function {
+	return Filter{}
+}
+
+// Filter implements the "filter" function.
+type Filter struct{}
+
+func (f Filter) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "filter"
+}
+
+func (f Filter) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Select the elements of a list with a Starlark lambda",
+		Description: "Returns the elements of the list for which the Starlark lambda, such as \"lambda x: x > 1\", returns a true value.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "list",
+				Description: "The list or tuple to filter.",
+			},
+			function.StringParameter{
+				Name:        "lambda",
+				Description: "A Starlark lambda that takes one element and returns whether to keep it.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f Filter) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var list types.Dynamic
+	var lambda string
+
+	resp.Error = req.Arguments.Get(ctx, &list, &lambda)
+	if resp.Error != nil {
+		return
+	}
+
+	thread := newLambdaThread("filter")
+	fn, err := compileLambda(thread, lambda)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	elems, err := lambdaList(ctx, list)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	var out []starlark.Value
+	for i := 0; i < elems.Len(); i++ {
+		keep, err := callLambda(thread, fn, i, elems.Index(i))
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		if keep.Truth() {
+			out = append(out, elems.Index(i))
+		}
+	}
+
+	tfVal, err := starlarkToTFValue(ctx, starlark.NewList(out))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert result: %s", err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(tfVal))
+}
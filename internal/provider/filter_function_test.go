@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFilterFunction_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "even" {
+					value = provider::starlark::filter([1, 2, 3, 4], "lambda x: x % 2 == 0")
+				}
+				output "enabled" {
+					value = provider::starlark::filter([{ name = "web", enabled = true }, { name = "db", enabled = false }], "lambda x: x[\"enabled\"]")
+				}
+				output "equal" {
+					value = provider::starlark::filter([{ name = "web" }, { name = "db" }], "lambda x: x == {\"name\": \"db\"} and type(x) == \"dict\"")
+				}
+				output "none" {
+					value = provider::starlark::filter(["a", "b"], "lambda x: x == \"c\"")
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("even", []interface{}{json.Number("2"), json.Number("4")}),
+					NewTestCheckOutput("enabled", []interface{}{map[string]interface{}{"name": "web", "enabled": true}}),
+					NewTestCheckOutput("equal", []interface{}{map[string]interface{}{"name": "db"}}),
+					NewTestCheckOutput("none", []interface{}{}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFilterFunction_errorIndex(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::filter(["a", "b", 3], "lambda x: x.startswith(\"a\")")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`lambda failed at index 2`),
+			},
+		},
+	})
+}
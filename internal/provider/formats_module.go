@@ -0,0 +1,674 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// formatsModule implements the "formats" module for application config formats.
+//
+// Parsers return plain dicts with string keys. Encoders sort keys, so decoding and re-encoding a
+// file produces stable output.
+var formatsModule = &starlarkstruct.Module{
+	Name: "formats",
+	Members: starlark.StringDict{
+		"parse_toml":        starlark.NewBuiltin("formats.parse_toml", formatsParseTOML),
+		"encode_toml":       starlark.NewBuiltin("formats.encode_toml", formatsEncodeTOML),
+		"parse_ini":         starlark.NewBuiltin("formats.parse_ini", formatsParseINI),
+		"encode_ini":        starlark.NewBuiltin("formats.encode_ini", formatsEncodeINI),
+		"parse_dotenv":      starlark.NewBuiltin("formats.parse_dotenv", formatsParseDotenv),
+		"encode_dotenv":     starlark.NewBuiltin("formats.encode_dotenv", formatsEncodeDotenv),
+		"parse_properties":  starlark.NewBuiltin("formats.parse_properties", formatsParseProperties),
+		"encode_properties": starlark.NewBuiltin("formats.encode_properties", formatsEncodeProperties),
+	},
+}
+
+// TOML
+
+func formatsParseTOML(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if _, err := toml.Decode(s, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return tomlToStarlark(doc)
+}
+
+func tomlToStarlark(v interface{}) (starlark.Value, error) {
+	switch v := v.(type) {
+	case string:
+		return starlark.String(v), nil
+	case bool:
+		return starlark.Bool(v), nil
+	case int64:
+		return starlark.MakeInt64(v), nil
+	case float64:
+		return starlark.Float(v), nil
+	case time.Time:
+		// Datetimes are returned as strings in their TOML form.
+		switch v.Location().String() {
+		case "datetime-local":
+			return starlark.String(v.Format("2006-01-02T15:04:05.999999999")), nil
+		case "date-local":
+			return starlark.String(v.Format("2006-01-02")), nil
+		case "time-local":
+			return starlark.String(v.Format("15:04:05.999999999")), nil
+		default:
+			return starlark.String(v.Format(time.RFC3339Nano)), nil
+		}
+	case []interface{}:
+		elems := make([]starlark.Value, 0, len(v))
+		for _, e := range v {
+			conv, err := tomlToStarlark(e)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, conv)
+		}
+		return starlark.NewList(elems), nil
+	case []map[string]interface{}:
+		elems := make([]starlark.Value, 0, len(v))
+		for _, e := range v {
+			conv, err := tomlToStarlark(e)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, conv)
+		}
+		return starlark.NewList(elems), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		dict := starlark.NewDict(len(v))
+		for _, k := range keys {
+			conv, err := tomlToStarlark(v[k])
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(k), conv); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	default:
+		return nil, fmt.Errorf("unsupported TOML value %T", v)
+	}
+}
+
+func formatsEncodeTOML(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.IterableMapping
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value); err != nil {
+		return nil, err
+	}
+	doc, err := starlarkToTOML(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(buf.String()), nil
+}
+
+// starlarkToTOML converts a Starlark value to the Go representation expected by the TOML encoder.
+// None values in tables are omitted, since TOML has no null.
+func starlarkToTOML(v starlark.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		i, ok := v.Int64()
+		if !ok {
+			return nil, fmt.Errorf("integer %s is out of range for TOML", v)
+		}
+		return i, nil
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.IterableMapping:
+		table := make(map[string]interface{})
+		for _, item := range v.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("%s has %s key, want string", v.Type(), item[0].Type())
+			}
+			if item[1] == starlark.None {
+				continue
+			}
+			conv, err := starlarkToTOML(item[1])
+			if err != nil {
+				return nil, fmt.Errorf("in key %q: %s", string(k), err)
+			}
+			table[string(k)] = conv
+		}
+		return table, nil
+	case starlark.Indexable:
+		elems := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			conv, err := starlarkToTOML(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %s", i, err)
+			}
+			elems = append(elems, conv)
+		}
+		return elems, nil
+	default:
+		return nil, fmt.Errorf("cannot encode %s as TOML", v.Type())
+	}
+}
+
+// INI
+
+// formatsParseINI parses an INI file. Keys before the first section are returned at the top level,
+// and each section becomes a nested dict. All values are strings.
+func formatsParseINI(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+
+	root := starlark.NewDict(0)
+	current := root
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s: line %d: unterminated section header", b.Name(), lineno)
+			}
+			name := starlark.String(strings.TrimSpace(line[1 : len(line)-1]))
+			existing, found, _ := root.Get(name)
+			section, ok := existing.(*starlark.Dict)
+			if found && !ok {
+				return nil, fmt.Errorf("%s: line %d: section %s clashes with a key", b.Name(), lineno, name)
+			}
+			if !found {
+				section = starlark.NewDict(0)
+				if err := root.SetKey(name, section); err != nil {
+					return nil, err
+				}
+			}
+			current = section
+			continue
+		}
+
+		i := strings.IndexAny(line, "=:")
+		if i < 0 {
+			return nil, fmt.Errorf("%s: line %d: expected key = value", b.Name(), lineno)
+		}
+		key := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		if err := current.SetKey(starlark.String(key), starlark.String(value)); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return root, nil
+}
+
+// formatsEncodeINI renders a dict as INI. Scalar values are written as global keys and dict values as sections.
+func formatsEncodeINI(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value); err != nil {
+		return nil, err
+	}
+
+	globals, err := sortedStringItems(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var buf strings.Builder
+	var sections []string
+	sectionValues := map[string]*starlark.Dict{}
+	for _, item := range globals {
+		if section, ok := item.value.(*starlark.Dict); ok {
+			sections = append(sections, item.key)
+			sectionValues[item.key] = section
+			continue
+		}
+		s, err := iniValue(item.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q: %s", b.Name(), item.key, err)
+		}
+		fmt.Fprintf(&buf, "%s = %s\n", item.key, s)
+	}
+
+	for _, name := range sections {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "[%s]\n", name)
+		items, err := sortedStringItems(sectionValues[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: section %q: %s", b.Name(), name, err)
+		}
+		for _, item := range items {
+			s, err := iniValue(item.value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: section %q, key %q: %s", b.Name(), name, item.key, err)
+			}
+			fmt.Fprintf(&buf, "%s = %s\n", item.key, s)
+		}
+	}
+	return starlark.String(buf.String()), nil
+}
+
+// iniValue formats a value for encode_ini. Values that parse_ini would change, because they have
+// leading or trailing whitespace, quotes or comment characters, are wrapped in double quotes, which
+// parse_ini strips again.
+func iniValue(v starlark.Value) (string, error) {
+	s, err := configScalar(v)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(s, "\r\n") {
+		return "", fmt.Errorf("cannot encode a value with a line break")
+	}
+	if s != strings.TrimSpace(s) || strings.ContainsAny(s, "\"';#") {
+		return `"` + s + `"`, nil
+	}
+	return s, nil
+}
+
+// dotenv
+
+// formatsParseDotenv parses a .env file. Lines may start with "export". Double-quoted values support
+// escapes and may span lines; single-quoted values are literal; unquoted values end at " #".
+func formatsParseDotenv(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+
+	dict := starlark.NewDict(0)
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineno := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("%s: line %d: expected KEY=VALUE", b.Name(), lineno)
+		}
+		key := strings.TrimSpace(line[:eq])
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s: line %d: invalid key %q", b.Name(), lineno, key)
+		}
+		rest := strings.TrimSpace(line[eq+1:])
+
+		var value string
+		switch {
+		case strings.HasPrefix(rest, `"`):
+			// Double-quoted values may continue on following lines.
+			raw := rest[1:]
+			for {
+				end := closingQuote(raw)
+				if end >= 0 {
+					unquoted, err := unescapeDotenv(raw[:end])
+					if err != nil {
+						return nil, fmt.Errorf("%s: line %d: %s", b.Name(), lineno, err)
+					}
+					value = unquoted
+					break
+				}
+				i++
+				if i >= len(lines) {
+					return nil, fmt.Errorf("%s: line %d: unterminated quoted value", b.Name(), lineno)
+				}
+				raw += "\n" + lines[i]
+			}
+		case strings.HasPrefix(rest, "'"):
+			end := strings.Index(rest[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("%s: line %d: unterminated quoted value", b.Name(), lineno)
+			}
+			value = rest[1 : end+1]
+		default:
+			if j := strings.Index(rest, " #"); j >= 0 {
+				rest = rest[:j]
+			}
+			value = strings.TrimSpace(rest)
+		}
+		if err := dict.SetKey(starlark.String(key), starlark.String(value)); err != nil {
+			return nil, err
+		}
+	}
+	return dict, nil
+}
+
+// closingQuote returns the index of the first unescaped double quote in s, or -1.
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(s string) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			buf.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", fmt.Errorf("trailing backslash")
+		}
+		switch s[i] {
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return buf.String(), nil
+}
+
+func formatsEncodeDotenv(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value); err != nil {
+		return nil, err
+	}
+	items, err := sortedStringItems(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var buf strings.Builder
+	for _, item := range items {
+		s, err := configScalar(item.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q: %s", b.Name(), item.key, err)
+		}
+		if s == "" || strings.ContainsAny(s, " \t\r\n\"'#\\$`=") {
+			s = quoteDotenv(s)
+		}
+		fmt.Fprintf(&buf, "%s=%s\n", item.key, s)
+	}
+	return starlark.String(buf.String()), nil
+}
+
+// quoteDotenv quotes a value for a .env file. Single quotes are literal everywhere, so they are used
+// unless the value contains a single quote or a line break. Otherwise the value is double-quoted,
+// with $ and ` escaped so that tools such as docker compose and python-dotenv do not expand them.
+func quoteDotenv(s string) string {
+	if !strings.ContainsAny(s, "'\r\n") {
+		return "'" + s + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "$", `\$`, "`", "\\`")
+	return `"` + r.Replace(s) + `"`
+}
+
+// Java properties
+
+// formatsParseProperties parses a Java .properties file, including line continuations and escapes.
+func formatsParseProperties(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+
+	dict := starlark.NewDict(0)
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineno := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// A line ending in an odd number of backslashes continues on the next line.
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		// The key ends at the first unescaped '=', ':' or whitespace.
+		end := len(line)
+		for j := 0; j < len(line); j++ {
+			if line[j] == '\\' {
+				j++
+				continue
+			}
+			if strings.IndexByte("=: \t\f", line[j]) >= 0 {
+				end = j
+				break
+			}
+		}
+		rawKey := line[:end]
+		rest := strings.TrimLeft(line[end:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+
+		key, err := unescapeProperties(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: %s", b.Name(), lineno, err)
+		}
+		value, err := unescapeProperties(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: %s", b.Name(), lineno, err)
+		}
+		if err := dict.SetKey(starlark.String(key), starlark.String(value)); err != nil {
+			return nil, err
+		}
+	}
+	return dict, nil
+}
+
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+func unescapeProperties(s string) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			buf.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			break
+		}
+		switch s[i] {
+		case 't':
+			buf.WriteByte('\t')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'u':
+			r, ok := parseUnicodeEscape(s[i+1:])
+			if !ok {
+				return "", fmt.Errorf("malformed \\u escape")
+			}
+			i += 4
+			// Characters outside the BMP are written as a surrogate pair of escapes.
+			if utf16.IsSurrogate(r) && strings.HasPrefix(s[i+1:], `\u`) {
+				if low, ok := parseUnicodeEscape(s[i+3:]); ok {
+					if pair := utf16.DecodeRune(r, low); pair != utf8.RuneError {
+						r = pair
+						i += 6
+					}
+				}
+			}
+			buf.WriteRune(r)
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return buf.String(), nil
+}
+
+// parseUnicodeEscape parses the four hex digits at the start of s.
+func parseUnicodeEscape(s string) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	r, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return rune(r), true
+}
+
+func formatsEncodeProperties(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value *starlark.Dict
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value); err != nil {
+		return nil, err
+	}
+	items, err := sortedStringItems(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var buf strings.Builder
+	for _, item := range items {
+		s, err := configScalar(item.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q: %s", b.Name(), item.key, err)
+		}
+		fmt.Fprintf(&buf, "%s=%s\n", escapeProperties(item.key, true), escapeProperties(s, false))
+	}
+	return starlark.String(buf.String()), nil
+}
+
+// escapeProperties escapes s for a .properties file, writing non-ASCII characters as \uXXXX.
+func escapeProperties(s string, isKey bool) string {
+	var buf strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				buf.WriteByte('\\')
+			}
+			buf.WriteByte(' ')
+		default:
+			if r < 0x20 || r > 0x7e {
+				for _, u := range utf16Units(r) {
+					fmt.Fprintf(&buf, `\u%04x`, u)
+				}
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	return buf.String()
+}
+
+func utf16Units(r rune) []uint16 {
+	if r == utf8.RuneError || r < 0x10000 {
+		return []uint16{uint16(r)}
+	}
+	r -= 0x10000
+	return []uint16{uint16(0xd800 + (r>>10)&0x3ff), uint16(0xdc00 + r&0x3ff)}
+}
+
+// Shared helpers
+
+type stringItem struct {
+	key   string
+	value starlark.Value
+}
+
+// sortedStringItems returns the items of a dict sorted by key, checking that every key is a string.
+func sortedStringItems(d *starlark.Dict) ([]stringItem, error) {
+	items := make([]stringItem, 0, d.Len())
+	for _, item := range d.Items() {
+		k, ok := item[0].(starlark.String)
+		if !ok {
+			return nil, fmt.Errorf("keys must be strings, got %s", item[0].Type())
+		}
+		items = append(items, stringItem{key: string(k), value: item[1]})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].key < items[j].key })
+	return items, nil
+}
+
+// configScalar renders a scalar value for the flat config formats, which only have strings.
+func configScalar(v starlark.Value) (string, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return "", nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bool:
+		return strconv.FormatBool(bool(v)), nil
+	case starlark.Int:
+		return v.String(), nil
+	case starlark.Float:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return "", fmt.Errorf("cannot encode non-finite float %v", v)
+		}
+		return displayString(v), nil
+	default:
+		return "", fmt.Errorf("cannot encode %s, want string, number or bool", v.Type())
+	}
+}
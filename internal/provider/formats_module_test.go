@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFormatsModule_toml(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval(
+						"result = formats.parse_toml(v)",
+						{ v = "name = \"api\"\nreplicas = 3\nreleased = 1979-05-27\n\n[[ports]]\nport = 80\n" }
+					)
+				}
+				output "encode" {
+					value = provider::starlark::eval(
+						"result = formats.encode_toml({'name': 'api', 'db': {'port': 5432}, 'unset': None})",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"name":     "api",
+						"replicas": json.Number("3"),
+						"released": "1979-05-27",
+						"ports":    []interface{}{map[string]interface{}{"port": json.Number("80")}},
+					}),
+					resource.TestCheckOutput("encode", "name = \"api\"\n\n[db]\nport = 5432\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFormatsModule_ini(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval(
+						"result = formats.parse_ini(v)",
+						{ v = "debug = true\n; comment\n[db]\nhost = \"localhost\"\nport: 5432\n" }
+					)
+				}
+				output "encode" {
+					value = provider::starlark::eval(
+						"result = formats.encode_ini({'db': {'port': 5432, 'host': 'localhost'}, 'debug': True})",
+						{}
+					)
+				}
+				output "round_trip" {
+					value = provider::starlark::eval(
+						"result = formats.parse_ini(formats.encode_ini(v))",
+						{ v = { padded = "  padded  ", comment = "a ; b # c" } }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"debug": "true",
+						"db":    map[string]interface{}{"host": "localhost", "port": "5432"},
+					}),
+					resource.TestCheckOutput("encode", "debug = true\n\n[db]\nhost = localhost\nport = 5432\n"),
+					NewTestCheckOutput("round_trip", map[string]interface{}{"padded": "  padded  ", "comment": "a ; b # c"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFormatsModule_dotenv(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval(
+						"result = formats.parse_dotenv(v)",
+						{ v = "# comment\nexport A=1\nB=\"x\\ny\"\nC='$literal'\nD=value # comment\n" }
+					)
+				}
+				output "encode" {
+					value = provider::starlark::eval(
+						"result = formats.encode_dotenv({'B': 'two words', 'A': 1, 'C': 'a $HOME b', 'D': \"it's $HOME\"})",
+						{}
+					)
+				}
+				output "numbers" {
+					value = provider::starlark::eval(
+						"result = [formats.encode_dotenv(v), formats.encode_ini(v), formats.encode_properties(v)]",
+						{ v = { MAX_CONN = 1000000, RATIO = 1234567.5 } }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"A": "1",
+						"B": "x\ny",
+						"C": "$literal",
+						"D": "value",
+					}),
+					resource.TestCheckOutput("encode", "A=1\nB='two words'\nC='a $HOME b'\nD=\"it's \\$HOME\"\n"),
+					NewTestCheckOutput("numbers", []interface{}{
+						"MAX_CONN=1000000\nRATIO=1234567.5\n",
+						"MAX_CONN = 1000000\nRATIO = 1234567.5\n",
+						"MAX_CONN=1000000\nRATIO=1234567.5\n",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFormatsModule_properties(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parse" {
+					value = provider::starlark::eval(
+						"result = formats.parse_properties(v)",
+						{ v = "# comment\ngreeting = hello \\\n    world\nkey:value\ncaf\\u00e9=yes\n" }
+					)
+				}
+				output "encode" {
+					value = provider::starlark::eval(
+						"result = formats.encode_properties({'url': 'http://x', 'name': 'café'})",
+						{}
+					)
+				}
+				output "round_trip" {
+					value = provider::starlark::eval(
+						"result = formats.parse_properties(formats.encode_properties({'k': v}))['k']",
+						{ v = "😀 a=b" }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parse", map[string]interface{}{
+						"greeting": "hello world",
+						"key":      "value",
+						"café":     "yes",
+					}),
+					resource.TestCheckOutput("encode", "name=caf\\u00e9\nurl=http\\://x\n"),
+					resource.TestCheckOutput("round_trip", "😀 a=b"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFormatsModule_invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = formats.parse_dotenv('A=\"unterminated')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`unterminated quoted value`),
+			},
+		},
+	})
+}
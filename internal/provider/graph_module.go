@@ -0,0 +1,429 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// graphModule implements the "graph" module over dependency dicts such as {"app": ["db", "cache"]},
+// where each key depends on the nodes in its list.
+var graphModule = &starlarkstruct.Module{
+	Name: "graph",
+	Members: starlark.StringDict{
+		"toposort":   starlark.NewBuiltin("graph.toposort", graphToposort),
+		"waves":      starlark.NewBuiltin("graph.waves", graphWaves),
+		"find_cycle": starlark.NewBuiltin("graph.find_cycle", graphFindCycle),
+		"scc":        starlark.NewBuiltin("graph.scc", graphSCC),
+		"reachable":  starlark.NewBuiltin("graph.reachable", graphReachable),
+		"closure":    starlark.NewBuiltin("graph.closure", graphClosure),
+		"reduction":  starlark.NewBuiltin("graph.reduction", graphReduction),
+	},
+}
+
+// depGraph is a dependency graph with nodes numbered in order of first appearance: the keys of the
+// dict in order, then nodes that only appear as dependencies. Results list nodes in this order
+// wherever the graph leaves it open, so they are deterministic.
+type depGraph struct {
+	nodes []string
+	index map[string]int
+	deps  [][]int
+}
+
+func (g *depGraph) node(name string) int {
+	if i, ok := g.index[name]; ok {
+		return i
+	}
+	g.index[name] = len(g.nodes)
+	g.nodes = append(g.nodes, name)
+	g.deps = append(g.deps, nil)
+	return len(g.nodes) - 1
+}
+
+func newDepGraph(m starlark.IterableMapping) (*depGraph, error) {
+	g := &depGraph{index: make(map[string]int)}
+	items := m.Items()
+	for _, item := range items {
+		k, ok := item[0].(starlark.String)
+		if !ok {
+			return nil, fmt.Errorf("nodes must be strings, got %s", item[0].Type())
+		}
+		g.node(string(k))
+	}
+	for _, item := range items {
+		name := string(item[0].(starlark.String))
+		from := g.index[name]
+		if item[1] == starlark.None {
+			continue
+		}
+		values, err := iterableValues(item[1])
+		if err != nil {
+			return nil, fmt.Errorf("dependencies of %q: %s", name, err)
+		}
+		seen := make(map[int]bool)
+		for _, v := range values {
+			s, ok := v.(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("dependencies of %q: nodes must be strings, got %s", name, v.Type())
+			}
+			to := g.node(string(s))
+			if !seen[to] {
+				seen[to] = true
+				g.deps[from] = append(g.deps[from], to)
+			}
+		}
+	}
+	return g, nil
+}
+
+// reversed returns the graph with every edge reversed, so that each node lists its dependents.
+func (g *depGraph) reversed() *depGraph {
+	r := &depGraph{nodes: g.nodes, index: g.index, deps: make([][]int, len(g.nodes))}
+	for from, deps := range g.deps {
+		for _, to := range deps {
+			r.deps[to] = append(r.deps[to], from)
+		}
+	}
+	return r
+}
+
+// findCycle returns a cycle as a path that starts and ends at the same node, or nil.
+func (g *depGraph) findCycle() []int {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make([]int, len(g.nodes))
+	var stack []int
+	var cycle []int
+	var visit func(n int) bool
+	visit = func(n int) bool {
+		state[n] = active
+		stack = append(stack, n)
+		for _, d := range g.deps[n] {
+			switch state[d] {
+			case active:
+				for i, s := range stack {
+					if s == d {
+						cycle = append(append([]int{}, stack[i:]...), d)
+						return true
+					}
+				}
+			case unvisited:
+				if visit(d) {
+					return true
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = done
+		return false
+	}
+	for n := range g.nodes {
+		if state[n] == unvisited && visit(n) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+func (g *depGraph) cycleError(cycle []int) error {
+	return fmt.Errorf("cycle: %s", strings.Join(g.names(cycle), " -> "))
+}
+
+func (g *depGraph) names(ids []int) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = g.nodes[id]
+	}
+	return names
+}
+
+// toposort returns the nodes with every node after its dependencies. Of the nodes that are ready
+// at each step, the first in input order is taken.
+func (g *depGraph) toposort() ([]int, error) {
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, g.cycleError(cycle)
+	}
+	dependents := g.reversed().deps
+	waiting := make([]int, len(g.nodes))
+	ready := make([]bool, len(g.nodes))
+	for n, deps := range g.deps {
+		waiting[n] = len(deps)
+		ready[n] = len(deps) == 0
+	}
+	order := make([]int, 0, len(g.nodes))
+	for len(order) < len(g.nodes) {
+		next := -1
+		for n, ok := range ready {
+			if ok {
+				next = n
+				break
+			}
+		}
+		ready[next] = false
+		order = append(order, next)
+		for _, d := range dependents[next] {
+			if waiting[d]--; waiting[d] == 0 {
+				ready[d] = true
+			}
+		}
+	}
+	return order, nil
+}
+
+// waves groups the nodes into waves that can be processed in parallel: a node is in the wave after
+// the last wave of its dependencies.
+func (g *depGraph) waves() ([][]int, error) {
+	order, err := g.toposort()
+	if err != nil {
+		return nil, err
+	}
+	wave := make([]int, len(g.nodes))
+	count := 0
+	for _, n := range order {
+		for _, d := range g.deps[n] {
+			wave[n] = max(wave[n], wave[d]+1)
+		}
+		count = max(count, wave[n]+1)
+	}
+	waves := make([][]int, count)
+	for n := range g.nodes {
+		waves[wave[n]] = append(waves[wave[n]], n)
+	}
+	return waves, nil
+}
+
+// scc returns the strongly connected components with Tarjan's algorithm, dependencies first and
+// each component in input order.
+func (g *depGraph) scc() [][]int {
+	index := make([]int, len(g.nodes))
+	low := make([]int, len(g.nodes))
+	onStack := make([]bool, len(g.nodes))
+	for n := range index {
+		index[n] = -1
+	}
+	var stack []int
+	var components [][]int
+	next := 0
+	var connect func(n int)
+	connect = func(n int) {
+		index[n], low[n] = next, next
+		next++
+		stack = append(stack, n)
+		onStack[n] = true
+		for _, d := range g.deps[n] {
+			if index[d] < 0 {
+				connect(d)
+				low[n] = min(low[n], low[d])
+			} else if onStack[d] {
+				low[n] = min(low[n], index[d])
+			}
+		}
+		if low[n] != index[n] {
+			return
+		}
+		members := make([]bool, len(g.nodes))
+		for {
+			m := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[m] = false
+			members[m] = true
+			if m == n {
+				break
+			}
+		}
+		components = append(components, setToIDs(members))
+	}
+	for n := range g.nodes {
+		if index[n] < 0 {
+			connect(n)
+		}
+	}
+	return components
+}
+
+// reachable returns the set of nodes reachable from start through one or more edges. start itself
+// is only included if it is on a cycle.
+func (g *depGraph) reachable(start int) []bool {
+	seen := make([]bool, len(g.nodes))
+	queue := append([]int{}, g.deps[start]...)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		queue = append(queue, g.deps[n]...)
+	}
+	return seen
+}
+
+func setToIDs(set []bool) []int {
+	var ids []int
+	for n, ok := range set {
+		if ok {
+			ids = append(ids, n)
+		}
+	}
+	return ids
+}
+
+func (g *depGraph) nameList(ids []int) *starlark.List {
+	values := make([]starlark.Value, len(ids))
+	for i, id := range ids {
+		values[i] = starlark.String(g.nodes[id])
+	}
+	return starlark.NewList(values)
+}
+
+// nodeDict returns a dict with an entry for every node in input order.
+func (g *depGraph) nodeDict(lists [][]int) *starlark.Dict {
+	d := starlark.NewDict(len(g.nodes))
+	for n, ids := range lists {
+		_ = d.SetKey(starlark.String(g.nodes[n]), g.nameList(ids))
+	}
+	return d
+}
+
+func unpackGraph(b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (*depGraph, error) {
+	var m starlark.IterableMapping
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "graph", &m); err != nil {
+		return nil, err
+	}
+	g, err := newDepGraph(m)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return g, nil
+}
+
+func graphToposort(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	g, err := unpackGraph(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	order, err := g.toposort()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return g.nameList(order), nil
+}
+
+func graphWaves(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	g, err := unpackGraph(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	waves, err := g.waves()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	values := make([]starlark.Value, len(waves))
+	for i, wave := range waves {
+		values[i] = g.nameList(wave)
+	}
+	return starlark.NewList(values), nil
+}
+
+func graphFindCycle(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	g, err := unpackGraph(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	cycle := g.findCycle()
+	if cycle == nil {
+		return starlark.None, nil
+	}
+	return g.nameList(cycle), nil
+}
+
+func graphSCC(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	g, err := unpackGraph(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	components := g.scc()
+	values := make([]starlark.Value, len(components))
+	for i, c := range components {
+		values[i] = g.nameList(c)
+	}
+	return starlark.NewList(values), nil
+}
+
+// graphReachable returns the transitive dependencies of a node or, with reverse=True, the nodes
+// that depend on it directly or indirectly.
+func graphReachable(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var m starlark.IterableMapping
+	var start string
+	reverse := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "graph", &m, "start", &start, "reverse?", &reverse); err != nil {
+		return nil, err
+	}
+	g, err := newDepGraph(m)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	n, ok := g.index[start]
+	if !ok {
+		return nil, fmt.Errorf("%s: node %q is not in the graph", b.Name(), start)
+	}
+	if reverse {
+		g = g.reversed()
+	}
+	return g.nameList(setToIDs(g.reachable(n))), nil
+}
+
+// graphClosure returns the transitive closure: every node mapped to all the nodes it depends on
+// directly or indirectly.
+func graphClosure(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	g, err := unpackGraph(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	closure := make([][]int, len(g.nodes))
+	for n := range g.nodes {
+		closure[n] = setToIDs(g.reachable(n))
+	}
+	return g.nodeDict(closure), nil
+}
+
+// graphReduction returns the transitive reduction: every node mapped to the dependencies that are
+// not also reachable through another dependency. The graph must be acyclic.
+func graphReduction(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	g, err := unpackGraph(b, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), g.cycleError(cycle))
+	}
+	reach := make([][]bool, len(g.nodes))
+	for n := range g.nodes {
+		reach[n] = g.reachable(n)
+	}
+	reduced := make([][]int, len(g.nodes))
+	for n, deps := range g.deps {
+		reduced[n] = []int{}
+		for _, d := range deps {
+			implied := false
+			for _, other := range deps {
+				if other != d && reach[other][d] {
+					implied = true
+					break
+				}
+			}
+			if !implied {
+				reduced[n] = append(reduced[n], d)
+			}
+		}
+	}
+	return g.nodeDict(reduced), nil
+}
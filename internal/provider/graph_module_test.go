@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccGraphModule_order(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					services = {
+						app     = ["db", "cache", "network"]
+						db      = ["network"]
+						cache   = ["network"]
+						worker  = ["db"]
+						network = []
+					}
+				}
+				output "toposort" {
+					value = provider::starlark::eval("result = graph.toposort(g)", { g = local.services })
+				}
+				output "waves" {
+					value = provider::starlark::eval("result = graph.waves(g)", { g = local.services })
+				}
+				output "stable" {
+					value = provider::starlark::eval("result = graph.toposort({'z': ['y'], 'x': []})", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("toposort", []interface{}{"network", "cache", "db", "app", "worker"}),
+					NewTestCheckOutput("waves", []interface{}{
+						[]interface{}{"network"},
+						[]interface{}{"cache", "db"},
+						[]interface{}{"app", "worker"},
+					}),
+					NewTestCheckOutput("stable", []interface{}{"x", "y", "z"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGraphModule_cycles(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "find_cycle" {
+					value = provider::starlark::eval("result = graph.find_cycle({'a': ['b'], 'b': ['c'], 'c': ['a', 'd'], 'd': []})", {})
+				}
+				output "no_cycle" {
+					value = provider::starlark::eval("result = graph.find_cycle({'a': ['b'], 'b': []})", {})
+				}
+				output "scc" {
+					value = provider::starlark::eval("result = graph.scc({'a': ['b'], 'b': ['c'], 'c': ['a', 'd'], 'd': [], 'e': ['d', 'e']})", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("find_cycle", []interface{}{"a", "b", "c", "a"}),
+					NewTestCheckOutput("no_cycle", nil),
+					NewTestCheckOutput("scc", []interface{}{
+						[]interface{}{"d"},
+						[]interface{}{"a", "b", "c"},
+						[]interface{}{"e"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGraphModule_reachability(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					script = <<-EOT
+					g = {"app": ["db", "cache", "network"], "db": ["network"], "cache": ["network"], "worker": ["db"], "network": []}
+					result = {
+						"dependents": graph.reachable(g, "network", reverse = True),
+						"closure": graph.closure(g)["worker"],
+						"reduction": graph.reduction(g)["app"],
+					}
+					EOT
+				}
+				output "test" {
+					value = provider::starlark::eval(local.script, {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("test", map[string]interface{}{
+						"dependents": []interface{}{"app", "db", "cache", "worker"},
+						"closure":    []interface{}{"db", "network"},
+						"reduction":  []interface{}{"db", "cache"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGraphModule_toposortCycle(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = graph.toposort({'a': ['b'], 'b': ['a']})", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`cycle: a -> b -> a`),
+			},
+		},
+	})
+}
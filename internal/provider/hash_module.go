@@ -0,0 +1,184 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+	"math/big"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// hashModule implements the "hash" module.
+//
+// Every digest function takes the data as a string or bytes and an optional
+// encoding ("hex", "base64", "base64url" or "bytes") for the result. The
+// non-cryptographic crc32 and fnv checksums additionally accept "int".
+var hashModule = &starlarkstruct.Module{
+	Name: "hash",
+	Members: starlark.StringDict{
+		"md5":    starlark.NewBuiltin("hash.md5", digestBuiltin(md5.New)),
+		"sha1":   starlark.NewBuiltin("hash.sha1", digestBuiltin(sha1.New)),
+		"sha256": starlark.NewBuiltin("hash.sha256", digestBuiltin(sha256.New)),
+		"sha512": starlark.NewBuiltin("hash.sha512", digestBuiltin(sha512.New)),
+		"crc32":  starlark.NewBuiltin("hash.crc32", hashCRC32),
+		"fnv":    starlark.NewBuiltin("hash.fnv", hashFNV),
+		"hmac":   starlark.NewBuiltin("hash.hmac", hashHMAC),
+		"uuid5":  starlark.NewBuiltin("hash.uuid5", hashUUID5),
+	},
+}
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// uuidNamespaces are the well-known name spaces defined in RFC 4122, appendix C.
+var uuidNamespaces = map[string]string{
+	"dns":  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	"url":  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	"oid":  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+	"x500": "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+}
+
+func digestBuiltin(newHash func() hash.Hash) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var data bytesOrString
+		encoding := "hex"
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data, "encoding?", &encoding); err != nil {
+			return nil, err
+		}
+		h := newHash()
+		h.Write(data)
+		return encodeDigest(b.Name(), h.Sum(nil), encoding)
+	}
+}
+
+func hashCRC32(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	encoding := "hex"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data, "encoding?", &encoding); err != nil {
+		return nil, err
+	}
+	sum := crc32.ChecksumIEEE(data)
+	if encoding == "int" {
+		return starlark.MakeUint(uint(sum)), nil
+	}
+	return encodeDigest(b.Name(), binary.BigEndian.AppendUint32(nil, sum), encoding)
+}
+
+func hashFNV(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data bytesOrString
+	bits := 64
+	encoding := "hex"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "data", &data, "bits?", &bits, "encoding?", &encoding); err != nil {
+		return nil, err
+	}
+
+	var h hash.Hash
+	switch bits {
+	case 32:
+		h = fnv.New32a()
+	case 64:
+		h = fnv.New64a()
+	case 128:
+		h = fnv.New128a()
+	default:
+		return nil, fmt.Errorf("%s: bits must be 32, 64 or 128, got %d", b.Name(), bits)
+	}
+	h.Write(data)
+	sum := h.Sum(nil)
+
+	if encoding == "int" {
+		return starlark.MakeBigInt(new(big.Int).SetBytes(sum)), nil
+	}
+	return encodeDigest(b.Name(), sum, encoding)
+}
+
+func hashHMAC(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key, data bytesOrString
+	algorithm := "sha256"
+	encoding := "hex"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "data", &data, "algorithm?", &algorithm, "encoding?", &encoding); err != nil {
+		return nil, err
+	}
+
+	newHash, ok := hashAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported algorithm %q", b.Name(), algorithm)
+	}
+	mac := hmac.New(newHash, key)
+	mac.Write(data)
+	return encodeDigest(b.Name(), mac.Sum(nil), encoding)
+}
+
+// hashUUID5 returns the name-based (SHA-1) UUID of name within namespace, as defined in RFC 4122, section 4.3.
+func hashUUID5(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var namespace string
+	var name bytesOrString
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "namespace", &namespace, "name", &name); err != nil {
+		return nil, err
+	}
+
+	if wellKnown, ok := uuidNamespaces[strings.ToLower(namespace)]; ok {
+		namespace = wellKnown
+	}
+	ns, err := parseUUID(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid namespace: %s", b.Name(), err)
+	}
+
+	h := sha1.New()
+	h.Write(ns)
+	h.Write(name)
+	u := h.Sum(nil)[:16]
+	u[6] = (u[6] & 0x0f) | 0x50 // version 5
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	s := hex.EncodeToString(u)
+	return starlark.String(s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]), nil
+}
+
+func parseUUID(s string) ([]byte, error) {
+	raw := strings.ReplaceAll(strings.Trim(s, "{}"), "-", "")
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("%q is not a UUID", s)
+	}
+	u, err := hex.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a UUID", s)
+	}
+	return u, nil
+}
+
+// encodeDigest renders sum in the requested encoding.
+func encodeDigest(fnname string, sum []byte, encoding string) (starlark.Value, error) {
+	switch encoding {
+	case "hex":
+		return starlark.String(hex.EncodeToString(sum)), nil
+	case "base64":
+		return starlark.String(base64.StdEncoding.EncodeToString(sum)), nil
+	case "base64url":
+		return starlark.String(base64.URLEncoding.EncodeToString(sum)), nil
+	case "bytes":
+		return starlark.Bytes(sum), nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported encoding %q", fnname, encoding)
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccHashModule_digests(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "md5" {
+					value = provider::starlark::eval("result = hash.md5(v)", { v = "hello" })
+				}
+				output "sha1" {
+					value = provider::starlark::eval("result = hash.sha1(v)", { v = "hello" })
+				}
+				output "sha256" {
+					value = provider::starlark::eval("result = hash.sha256(v)", { v = "hello" })
+				}
+				output "sha256_base64" {
+					value = provider::starlark::eval("result = hash.sha256(v, encoding = 'base64')", { v = "hello" })
+				}
+				output "crc32" {
+					value = provider::starlark::eval("result = hash.crc32(v)", { v = "hello" })
+				}
+				output "fnv32" {
+					value = provider::starlark::eval("result = hash.fnv(v, bits = 32)", { v = "hello" })
+				}
+				output "hmac" {
+					value = provider::starlark::eval("result = hash.hmac('key', v)", { v = "The quick brown fox jumps over the lazy dog" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("md5", "5d41402abc4b2a76b9719d911017c592"),
+					resource.TestCheckOutput("sha1", "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"),
+					resource.TestCheckOutput("sha256", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+					resource.TestCheckOutput("sha256_base64", "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="),
+					resource.TestCheckOutput("crc32", "3610a686"),
+					resource.TestCheckOutput("fnv32", "4f9f2cab"),
+					resource.TestCheckOutput("hmac", "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHashModule_uuid5(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "well_known_namespace" {
+					value = provider::starlark::eval("result = hash.uuid5('dns', v)", { v = "python.org" })
+				}
+				output "custom_namespace" {
+					value = provider::starlark::eval("result = hash.uuid5('6ba7b810-9dad-11d1-80b4-00c04fd430c8', v)", { v = "python.org" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("well_known_namespace", "886313e1-3b8a-5372-9b90-0c9aee199e5d"),
+					resource.TestCheckOutput("custom_namespace", "886313e1-3b8a-5372-9b90-0c9aee199e5d"),
+				),
+			},
+		},
+	})
+}
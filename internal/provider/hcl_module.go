@@ -0,0 +1,152 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// hclModule implements the "hcl" module.
+//
+// Only literal HCL is supported: expressions may not call functions, and may only
+// refer to the variables passed to hcl.eval.
+var hclModule = &starlarkstruct.Module{
+	Name: "hcl",
+	Members: starlark.StringDict{
+		"decode": starlark.NewBuiltin("hcl.decode", hclDecode),
+		"eval":   starlark.NewBuiltin("hcl.eval", hclEval),
+		"encode": starlark.NewBuiltin("hcl.encode", hclEncode),
+	},
+}
+
+// hclDecode decodes an HCL body made only of attributes, such as a .tfvars file, into a dict.
+func hclDecode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var src string
+	filename := "input.tfvars"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "src", &src, "filename?", &filename); err != nil {
+		return nil, err
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("%s: %s", b.Name(), diags.Error())
+	}
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("%s: %s", b.Name(), diags.Error())
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dict := starlark.NewDict(len(attrs))
+	for _, name := range names {
+		val, diags := attrs[name].Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("%s: %s", b.Name(), diags.Error())
+		}
+		conv, err := ctyToStarlark(val)
+		if err != nil {
+			return nil, fmt.Errorf("%s: attribute %q: %s", b.Name(), name, err)
+		}
+		if err := dict.SetKey(starlark.String(name), conv); err != nil {
+			return nil, err
+		}
+	}
+	return dict, nil
+}
+
+// hclEval evaluates a single HCL expression, optionally with variables.
+func hclEval(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var src string
+	var variables *starlark.Dict
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "expr", &src, "variables?", &variables); err != nil {
+		return nil, err
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "expression.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("%s: %s", b.Name(), diags.Error())
+	}
+
+	ctx := &hcl.EvalContext{Variables: map[string]cty.Value{}}
+	if variables != nil {
+		for _, item := range variables.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("%s: variable names must be strings, got %s", b.Name(), item[0].Type())
+			}
+			val, err := starlarkToCty(item[1])
+			if err != nil {
+				return nil, fmt.Errorf("%s: variable %q: %s", b.Name(), string(k), err)
+			}
+			ctx.Variables[string(k)] = val
+		}
+	}
+
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("%s: %s", b.Name(), diags.Error())
+	}
+	conv, err := ctyToStarlark(val)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return conv, nil
+}
+
+// hclEncode renders a dict as formatted HCL attributes, sorted by name. When block is set,
+// the attributes are wrapped in a block of that type, e.g. "locals".
+func hclEncode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.IterableMapping
+	block := ""
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "block?", &block); err != nil {
+		return nil, err
+	}
+
+	items := value.Items()
+	attrs := make(map[string]cty.Value, len(items))
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		k, ok := item[0].(starlark.String)
+		if !ok {
+			return nil, fmt.Errorf("%s: attribute names must be strings, got %s", b.Name(), item[0].Type())
+		}
+		name := string(k)
+		if !hclsyntax.ValidIdentifier(name) {
+			return nil, fmt.Errorf("%s: %q is not a valid attribute name", b.Name(), name)
+		}
+		val, err := starlarkToCty(item[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: attribute %q: %s", b.Name(), name, err)
+		}
+		attrs[name] = val
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+	if block != "" {
+		if !hclsyntax.ValidIdentifier(block) {
+			return nil, fmt.Errorf("%s: %q is not a valid block type", b.Name(), block)
+		}
+		body = body.AppendNewBlock(block, nil).Body()
+	}
+	for _, name := range names {
+		body.SetAttributeValue(name, attrs[name])
+	}
+	return starlark.String(hclwrite.Format(file.Bytes())), nil
+}
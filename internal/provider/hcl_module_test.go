@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccHclModule_decode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "tfvars" {
+					value = provider::starlark::eval(
+						"result = hcl.decode(v)",
+						{ v = "location = \"westeurope\"\ninstances = 3\ntags = {\n  env = \"prod\"\n}\n" }
+					)
+				}
+				output "expression" {
+					value = provider::starlark::eval(
+						"result = hcl.eval('[for x in xs : x * 2]', { 'xs': v })",
+						{ v = [1, 2, 3] }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("tfvars", map[string]interface{}{
+						"location":  "westeurope",
+						"instances": json.Number("3"),
+						"tags": map[string]interface{}{
+							"env": "prod",
+						},
+					}),
+					NewTestCheckOutput("expression", []interface{}{json.Number("2"), json.Number("4"), json.Number("6")}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHclModule_encode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "tfvars" {
+					value = provider::starlark::eval(
+						"result = hcl.encode({'name': 'web', 'count': 3, 'zones': ['1', '2']})",
+						{}
+					)
+				}
+				output "locals" {
+					value = provider::starlark::eval(
+						"result = hcl.encode({'tags': {'env': 'prod'}}, block = 'locals')",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("tfvars", "count = 3\nname  = \"web\"\nzones = [\"1\", \"2\"]\n"),
+					resource.TestCheckOutput("locals", "locals {\n  tags = {\n    env = \"prod\"\n  }\n}\n"),
+				),
+			},
+		},
+	})
+}
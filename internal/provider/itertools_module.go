@@ -0,0 +1,424 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// defaultMaxItems is the default limit on the number of results an itertools function may produce.
+const defaultMaxItems = 10000
+
+// itertoolsModule implements the "itertools" module.
+//
+// Unlike Python's itertools, every function returns a list, and combinations are lists rather than
+// tuples so results can be returned to Terraform directly. Each function takes a max_items argument
+// and fails before doing any work if the result would have more elements than that.
+var itertoolsModule = &starlarkstruct.Module{
+	Name: "itertools",
+	Members: starlark.StringDict{
+		"product":        starlark.NewBuiltin("itertools.product", itertoolsProduct),
+		"combinations":   starlark.NewBuiltin("itertools.combinations", itertoolsCombinations),
+		"permutations":   starlark.NewBuiltin("itertools.permutations", itertoolsPermutations),
+		"chunked":        starlark.NewBuiltin("itertools.chunked", itertoolsChunked),
+		"sliding_window": starlark.NewBuiltin("itertools.sliding_window", itertoolsSlidingWindow),
+		"zip_longest":    starlark.NewBuiltin("itertools.zip_longest", itertoolsZipLongest),
+		"enumerate":      starlark.NewBuiltin("itertools.enumerate", itertoolsEnumerate),
+		"group_by":       starlark.NewBuiltin("itertools.group_by", itertoolsGroupBy),
+	},
+}
+
+// iterableValues collects the elements of an iterable. Strings are not iterable in Starlark,
+// so passing one is an error rather than a list of characters.
+func iterableValues(v starlark.Value) ([]starlark.Value, error) {
+	return iterableValuesMax(v, -1)
+}
+
+// iterableValuesMax is like iterableValues, but fails as soon as the iterable turns out to have
+// more than maxItems elements, so an input such as range(1000000000) is rejected without being
+// materialized. A negative maxItems means no limit.
+func iterableValuesMax(v starlark.Value, maxItems int) ([]starlark.Value, error) {
+	iterable, ok := v.(starlark.Iterable)
+	if !ok {
+		return nil, fmt.Errorf("got %s, want iterable", v.Type())
+	}
+	tooMany := fmt.Errorf("input has more than %d elements (max_items)", maxItems)
+	if seq, ok := v.(starlark.Sequence); ok && maxItems >= 0 && seq.Len() > maxItems {
+		return nil, tooMany
+	}
+	var values []starlark.Value
+	iter := iterable.Iterate()
+	defer iter.Done()
+	var x starlark.Value
+	for iter.Next(&x) {
+		if maxItems >= 0 && len(values) == maxItems {
+			return nil, tooMany
+		}
+		values = append(values, x)
+	}
+	return values, nil
+}
+
+// checkMaxItems fails if n, which may be +Inf after an overflow, exceeds maxItems.
+func checkMaxItems(n float64, maxItems int) error {
+	if n > float64(maxItems) {
+		if math.IsInf(n, 1) || n > 1e15 {
+			return fmt.Errorf("result would have more than %d elements (max_items)", maxItems)
+		}
+		return fmt.Errorf("result would have %.0f elements, more than max_items (%d)", n, maxItems)
+	}
+	return nil
+}
+
+func itertoolsProduct(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	repeat := 1
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), nil, kwargs, "repeat?", &repeat, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	if repeat < 0 {
+		return nil, fmt.Errorf("%s: repeat must not be negative", b.Name())
+	}
+
+	var pools [][]starlark.Value
+	for i, arg := range args {
+		values, err := iterableValuesMax(arg, maxItems)
+		if err != nil {
+			return nil, fmt.Errorf("%s: argument %d: %s", b.Name(), i+1, err)
+		}
+		pools = append(pools, values)
+	}
+
+	size := 1.0
+	for _, pool := range pools {
+		size *= float64(len(pool))
+	}
+	if len(pools) > 0 {
+		size = math.Pow(size, float64(repeat))
+	}
+	if err := checkMaxItems(size, maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if width := float64(len(pools)) * float64(repeat); width > float64(maxItems) {
+		return nil, fmt.Errorf("%s: each result would have %.0f elements, more than max_items (%d)", b.Name(), width, maxItems)
+	}
+
+	var repeated [][]starlark.Value
+	for i := 0; i < repeat; i++ {
+		repeated = append(repeated, pools...)
+	}
+
+	results := [][]starlark.Value{nil}
+	for _, pool := range repeated {
+		next := make([][]starlark.Value, 0, len(results)*len(pool))
+		for _, prefix := range results {
+			for _, v := range pool {
+				combo := make([]starlark.Value, len(prefix), len(prefix)+1)
+				copy(combo, prefix)
+				next = append(next, append(combo, v))
+			}
+		}
+		results = next
+	}
+	return listOfLists(results), nil
+}
+
+func itertoolsCombinations(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Value
+	var r int
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "iterable", &iterable, "r", &r, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	pool, err := iterableValuesMax(iterable, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	n := len(pool)
+	if r < 0 {
+		return nil, fmt.Errorf("%s: r must not be negative", b.Name())
+	}
+	if r > n {
+		return starlark.NewList(nil), nil
+	}
+
+	// C(n, r), computed incrementally so intermediate values stay small.
+	size := 1.0
+	for i := 1; i <= r; i++ {
+		size = size * float64(n-r+i) / float64(i)
+	}
+	if err := checkMaxItems(math.Round(size), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var results [][]starlark.Value
+	indices := make([]int, r)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		combo := make([]starlark.Value, r)
+		for i, idx := range indices {
+			combo[i] = pool[idx]
+		}
+		results = append(results, combo)
+
+		// Find the rightmost index that can still move right.
+		i := r - 1
+		for i >= 0 && indices[i] == i+n-r {
+			i--
+		}
+		if i < 0 {
+			break
+		}
+		indices[i]++
+		for j := i + 1; j < r; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+	return listOfLists(results), nil
+}
+
+func itertoolsPermutations(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Value
+	var rValue starlark.Value = starlark.None
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "iterable", &iterable, "r?", &rValue, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	pool, err := iterableValuesMax(iterable, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	n := len(pool)
+	r := n
+	if rValue != starlark.None {
+		if err := starlark.AsInt(rValue, &r); err != nil {
+			return nil, fmt.Errorf("%s: r: %s", b.Name(), err)
+		}
+	}
+	if r < 0 {
+		return nil, fmt.Errorf("%s: r must not be negative", b.Name())
+	}
+	if r > n {
+		return starlark.NewList(nil), nil
+	}
+
+	size := 1.0
+	for i := 0; i < r; i++ {
+		size *= float64(n - i)
+	}
+	if err := checkMaxItems(size, maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var results [][]starlark.Value
+	used := make([]bool, n)
+	current := make([]starlark.Value, 0, r)
+	var visit func()
+	visit = func() {
+		if len(current) == r {
+			results = append(results, append([]starlark.Value(nil), current...))
+			return
+		}
+		for i := 0; i < n; i++ {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			current = append(current, pool[i])
+			visit()
+			current = current[:len(current)-1]
+			used[i] = false
+		}
+	}
+	visit()
+	return listOfLists(results), nil
+}
+
+// itertoolsChunked splits an iterable into lists of n elements. The last chunk may be shorter.
+func itertoolsChunked(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Value
+	var n int
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "iterable", &iterable, "n", &n, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("%s: n must be at least 1", b.Name())
+	}
+	values, err := iterableValuesMax(iterable, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if err := checkMaxItems(math.Ceil(float64(len(values))/float64(n)), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var results [][]starlark.Value
+	for i := 0; i < len(values); i += n {
+		end := i + n
+		if end > len(values) {
+			end = len(values)
+		}
+		results = append(results, values[i:end])
+	}
+	return listOfLists(results), nil
+}
+
+// itertoolsSlidingWindow returns overlapping windows of n consecutive elements.
+func itertoolsSlidingWindow(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Value
+	var n int
+	step := 1
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "iterable", &iterable, "n", &n, "step?", &step, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("%s: n must be at least 1", b.Name())
+	}
+	if step < 1 {
+		return nil, fmt.Errorf("%s: step must be at least 1", b.Name())
+	}
+	values, err := iterableValuesMax(iterable, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	count := 0
+	if len(values) >= n {
+		count = (len(values)-n)/step + 1
+	}
+	if err := checkMaxItems(float64(count), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	results := make([][]starlark.Value, 0, count)
+	for i := 0; i+n <= len(values); i += step {
+		results = append(results, values[i:i+n])
+	}
+	return listOfLists(results), nil
+}
+
+func itertoolsZipLongest(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var fillvalue starlark.Value = starlark.None
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), nil, kwargs, "fillvalue?", &fillvalue, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+
+	var columns [][]starlark.Value
+	longest := 0
+	for i, arg := range args {
+		values, err := iterableValuesMax(arg, maxItems)
+		if err != nil {
+			return nil, fmt.Errorf("%s: argument %d: %s", b.Name(), i+1, err)
+		}
+		columns = append(columns, values)
+		if len(values) > longest {
+			longest = len(values)
+		}
+	}
+	if err := checkMaxItems(float64(longest), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	results := make([][]starlark.Value, 0, longest)
+	for i := 0; i < longest; i++ {
+		row := make([]starlark.Value, len(columns))
+		for j, column := range columns {
+			if i < len(column) {
+				row[j] = column[i]
+			} else {
+				row[j] = fillvalue
+			}
+		}
+		results = append(results, row)
+	}
+	return listOfLists(results), nil
+}
+
+// itertoolsEnumerate is like the enumerate builtin, but with a start index and [index, value] lists instead of tuples.
+func itertoolsEnumerate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Value
+	start := 0
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "iterable", &iterable, "start?", &start, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	values, err := iterableValuesMax(iterable, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if err := checkMaxItems(float64(len(values)), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	results := make([][]starlark.Value, 0, len(values))
+	for i, v := range values {
+		results = append(results, []starlark.Value{starlark.MakeInt(start + i), v})
+	}
+	return listOfLists(results), nil
+}
+
+// itertoolsGroupBy groups consecutive elements with the same key into [key, elements] pairs.
+// Unlike table.group_by, equal keys that are not adjacent start a new group.
+func itertoolsGroupBy(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var iterable starlark.Value
+	var key starlark.Callable
+	maxItems := defaultMaxItems
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "iterable", &iterable, "key?", &key, "max_items?", &maxItems); err != nil {
+		return nil, err
+	}
+	values, err := iterableValuesMax(iterable, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if err := checkMaxItems(float64(len(values)), maxItems); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var results []starlark.Value
+	var currentKey starlark.Value
+	var current []starlark.Value
+	flush := func() {
+		if current != nil {
+			results = append(results, starlark.NewList([]starlark.Value{currentKey, starlark.NewList(current)}))
+		}
+	}
+	for _, v := range values {
+		k := v
+		if key != nil {
+			if k, err = starlark.Call(thread, key, starlark.Tuple{v}, nil); err != nil {
+				return nil, err
+			}
+		}
+		if current != nil {
+			eq, err := starlark.Equal(k, currentKey)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", b.Name(), err)
+			}
+			if eq {
+				current = append(current, v)
+				continue
+			}
+		}
+		flush()
+		currentKey, current = k, []starlark.Value{v}
+	}
+	flush()
+	return starlark.NewList(results), nil
+}
+
+func listOfLists(rows [][]starlark.Value) *starlark.List {
+	elems := make([]starlark.Value, len(rows))
+	for i, row := range rows {
+		elems[i] = starlark.NewList(append([]starlark.Value(nil), row...))
+	}
+	return starlark.NewList(elems)
+}
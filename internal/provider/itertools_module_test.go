@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccItertoolsModule_combinatorics(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "product" {
+					value = provider::starlark::eval(
+						"result = ['-'.join(p) for p in itertools.product(envs, regions)]",
+						{ envs = ["dev", "prod"], regions = ["east", "west"] }
+					)
+				}
+				output "combinations" {
+					value = provider::starlark::eval(
+						"result = itertools.combinations(['a', 'b', 'c'], 2)",
+						{}
+					)
+				}
+				output "permutations" {
+					value = provider::starlark::eval(
+						"result = itertools.permutations([1, 2, 3], 2)",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("product", []interface{}{"dev-east", "dev-west", "prod-east", "prod-west"}),
+					NewTestCheckOutput("combinations", []interface{}{
+						[]interface{}{"a", "b"},
+						[]interface{}{"a", "c"},
+						[]interface{}{"b", "c"},
+					}),
+					NewTestCheckOutput("permutations", []interface{}{
+						[]interface{}{json.Number("1"), json.Number("2")},
+						[]interface{}{json.Number("1"), json.Number("3")},
+						[]interface{}{json.Number("2"), json.Number("1")},
+						[]interface{}{json.Number("2"), json.Number("3")},
+						[]interface{}{json.Number("3"), json.Number("1")},
+						[]interface{}{json.Number("3"), json.Number("2")},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccItertoolsModule_sequences(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "chunked" {
+					value = provider::starlark::eval("result = itertools.chunked(['a', 'b', 'c'], 2)", {})
+				}
+				output "sliding_window" {
+					value = provider::starlark::eval("result = itertools.sliding_window(['a', 'b', 'c'], 2)", {})
+				}
+				output "zip_longest" {
+					value = provider::starlark::eval("result = itertools.zip_longest(['a', 'b'], ['x'], fillvalue = '-')", {})
+				}
+				output "enumerate" {
+					value = provider::starlark::eval("result = itertools.enumerate(['a', 'b'], start = 1)", {})
+				}
+				output "group_by" {
+					value = provider::starlark::eval(
+						"result = itertools.group_by(['apple', 'avocado', 'banana', 'apricot'], key = lambda s: s[0])",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("chunked", []interface{}{
+						[]interface{}{"a", "b"},
+						[]interface{}{"c"},
+					}),
+					NewTestCheckOutput("sliding_window", []interface{}{
+						[]interface{}{"a", "b"},
+						[]interface{}{"b", "c"},
+					}),
+					NewTestCheckOutput("zip_longest", []interface{}{
+						[]interface{}{"a", "x"},
+						[]interface{}{"b", "-"},
+					}),
+					NewTestCheckOutput("enumerate", []interface{}{
+						[]interface{}{json.Number("1"), "a"},
+						[]interface{}{json.Number("2"), "b"},
+					}),
+					NewTestCheckOutput("group_by", []interface{}{
+						[]interface{}{"a", []interface{}{"apple", "avocado"}},
+						[]interface{}{"b", []interface{}{"banana"}},
+						[]interface{}{"a", []interface{}{"apricot"}},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccItertoolsModule_maxItems(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = len(itertools.product(range(200), range(200), max_items = 50000))", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("test", "40000"),
+				),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = itertools.product(range(1000), range(1000))", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`result would have 1000000 elements, more than max_items \(10000\)`),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = itertools.chunked(range(1000000000), 2)", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`input has more than 10000 elements`),
+			},
+		},
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// newLambdaThread returns the thread that the map, filter, reduce and sort_by functions run on.
+func newLambdaThread(name string) *starlark.Thread {
+	return &starlark.Thread{
+		Name:  "terraform-provider-starlark-" + name,
+		Print: func(_ *starlark.Thread, msg string) { fmt.Println(msg) },
+	}
+}
+
+// compileLambda evaluates src, such as "lambda x: x * 2", once. The lambda can use the built-in
+// modules, and can read the keys of dicts as attributes, as in x.name.
+func compileLambda(thread *starlark.Thread, src string) (starlark.Callable, error) {
+	expr, err := scriptOptions.ParseExpr("lambda.star", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lambda: %s", err)
+	}
+	syntax.Walk(expr, func(n syntax.Node) bool {
+		if dot, ok := n.(*syntax.DotExpr); ok {
+			start, end := dot.X.Span()
+			dot.X = &syntax.CallExpr{
+				Fn:     &syntax.Ident{NamePos: start, Name: dictAttrsName},
+				Lparen: start,
+				Args:   []syntax.Expr{dot.X},
+				Rparen: end,
+			}
+		}
+		return true
+	})
+
+	env := predeclaredModules()
+	env[dictAttrsName] = starlark.NewBuiltin(dictAttrsName, dictAttrsBuiltin)
+	v, err := starlark.EvalExprOptions(scriptOptions, thread, expr, env)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lambda: %s", err)
+	}
+	fn, ok := v.(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("lambda must evaluate to a function, got %s", v.Type())
+	}
+	return fn, nil
+}
+
+// lambdaList converts the list argument of a higher-order function to a Starlark list.
+func lambdaList(ctx context.Context, list types.Dynamic) (*starlark.List, error) {
+	v, err := attrValueToStarlark(ctx, list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list: %s", err)
+	}
+	l, ok := v.(*starlark.List)
+	if !ok {
+		return nil, fmt.Errorf("list must be a list or tuple, got %s", v.Type())
+	}
+	return l, nil
+}
+
+// dictAttrsName is the predeclared name that compileLambda wraps the operand of every attribute
+// access in. It is not a valid identifier, so a lambda cannot shadow it.
+const dictAttrsName = "dict.attrs"
+
+// dictAttrsBuiltin returns the attributes view of a dict, and any other value as it is.
+func dictAttrsBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, _ []starlark.Tuple) (starlark.Value, error) {
+	if d, ok := args[0].(*starlark.Dict); ok {
+		return dictAttrs{d}, nil
+	}
+	return args[0], nil
+}
+
+// dictAttrs lets a lambda read the keys of a dict, such as a Terraform object, as attributes, so
+// x.name is x["name"]. A key takes precedence over a dict method of the same name. Only the
+// operand of an attribute access is wrapped, so x itself stays a dict and compares like one.
+type dictAttrs struct {
+	*starlark.Dict
+}
+
+var _ starlark.HasAttrs = dictAttrs{}
+
+func (d dictAttrs) Attr(name string) (starlark.Value, error) {
+	if v, found, _ := d.Dict.Get(starlark.String(name)); found {
+		return v, nil
+	}
+	return d.Dict.Attr(name)
+}
+
+func (d dictAttrs) AttrNames() []string {
+	names := d.Dict.AttrNames()
+	for _, k := range d.Dict.Keys() {
+		if s, ok := k.(starlark.String); ok {
+			names = append(names, string(s))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// callLambda calls fn for the element at index i, naming the element in the error.
+func callLambda(thread *starlark.Thread, fn starlark.Callable, i int, args ...starlark.Value) (starlark.Value, error) {
+	v, err := starlark.Call(thread, fn, starlark.Tuple(args), nil)
+	if err != nil {
+		return nil, fmt.Errorf("lambda failed at index %d: %s", i, err)
+	}
+	return v, nil
+}
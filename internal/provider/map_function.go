@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.starlark.net/starlark"
+)
+
+// Ensure the implementation satisfies the interface.
+var _ function.Function = Map{}
+
+func NewMapFunction() function.Function {
+	return Map{}
+}
+
+// Map implements the "map" function.
+type Map struct{}
+
+func (f Map) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "map"
+}
+
+func (f Map) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Transform each element of a list with a Starlark lambda",
+		Description: "Calls the Starlark lambda, such as \"lambda x: x * 2\", on each element of the list and returns the results.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "list",
+				Description: "The list or tuple to transform.",
+			},
+			function.StringParameter{
+				Name:        "lambda",
+				Description: "A Starlark lambda that takes one element and returns its new value.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f Map) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var list types.Dynamic
+	var lambda string
+
+	resp.Error = req.Arguments.Get(ctx, &list, &lambda)
+	if resp.Error != nil {
+		return
+	}
+
+	thread := newLambdaThread("map")
+	fn, err := compileLambda(thread, lambda)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	elems, err := lambdaList(ctx, list)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	out := make([]starlark.Value, elems.Len())
+	for i := range out {
+		if out[i], err = callLambda(thread, fn, i, elems.Index(i)); err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+
+	tfVal, err := starlarkToTFValue(ctx, starlark.NewList(out))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert result: %s", err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(tfVal))
+}
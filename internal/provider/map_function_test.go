@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccMapFunction_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "doubled" {
+					value = provider::starlark::map([1, 2, 3], "lambda x: x * 2")
+				}
+				output "names" {
+					value = provider::starlark::map([{ name = "web", port = 80 }, { name = "db", port = 5432 }], "lambda x: \"%s:%d\" % (x[\"name\"], x[\"port\"])")
+				}
+				output "attributes" {
+					value = provider::starlark::map([{ name = "web", tags = { env = "prod" } }], "lambda x: [x.name + '-' + x.tags.env, x['name'], type(x), x | {'port': 80}]")
+				}
+				output "modules" {
+					value = provider::starlark::map(["MyApp", "HTTPServer"], "lambda x: text.snake_case(x)")
+				}
+				output "empty" {
+					value = provider::starlark::map([], "lambda x: x")
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("doubled", []interface{}{json.Number("2"), json.Number("4"), json.Number("6")}),
+					NewTestCheckOutput("names", []interface{}{"web:80", "db:5432"}),
+					NewTestCheckOutput("attributes", []interface{}{
+						[]interface{}{"web-prod", "web", "dict", map[string]interface{}{"name": "web", "port": json.Number("80"), "tags": map[string]interface{}{"env": "prod"}}},
+					}),
+					NewTestCheckOutput("modules", []interface{}{"my_app", "http_server"}),
+					NewTestCheckOutput("empty", []interface{}{}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMapFunction_errorIndex(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::map([1, 0, 2], "lambda x: 10 // x")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`lambda failed at index 1`),
+			},
+		},
+	})
+}
+
+func TestAccMapFunction_notLambda(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::map([1, 2], "42")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`lambda must evaluate to a function`),
+			},
+		},
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+)
+
+// predeclaredModules returns the built-in modules that are available to every script.
+// Inputs with the same name as a module take precedence over it.
+func predeclaredModules() starlark.StringDict {
+	return starlark.StringDict{
+		"cloudid":     cloudidModule,
+		"collections": collectionsModule,
+		"cron":        cronModule,
+		"crypto":      cryptoModule,
+		"csv":         csvModule,
+		"decimal":     decimalModule,
+		"encoding":    encodingModule,
+		"formats":     formatsModule,
+		"graph":       graphModule,
+		"hash":        hashModule,
+		"hcl":         hclModule,
+		"itertools":   itertoolsModule,
+		"json":        json.Module,
+		"naming":      namingModule,
+		"patch":       patchModule,
+		"query":       queryModule,
+		"random":      randomModule,
+		"schema":      schemaModule,
+		"semver":      semverModule,
+		"table":       tableModule,
+		"text":        textModule,
+		"tf":          tfModule,
+		"units":       unitsModule,
+		"xml":         xmlModule,
+		"yaml":        yamlModule,
+	}
+}
+
+// bytesOrString unpacks a Starlark argument that may be either a string or bytes.
+type bytesOrString []byte
+
+func (b *bytesOrString) Unpack(v starlark.Value) error {
+	switch v := v.(type) {
+	case starlark.String:
+		*b = []byte(v)
+	case starlark.Bytes:
+		*b = []byte(v)
+	default:
+		return fmt.Errorf("got %s, want string or bytes", v.Type())
+	}
+	return nil
+}
+
+// displayString returns the text of v as it appears in rendered output. Strings are returned as
+// they are, and floats are written without an exponent between 1e-6 and 1e21, so Terraform's whole
+// numbers read as integers. Other values are formatted as str() does.
+func displayString(v starlark.Value) string {
+	switch v := v.(type) {
+	case starlark.String:
+		return string(v)
+	case starlark.Float:
+		if f := math.Abs(float64(v)); f == 0 || (f >= 1e-6 && f < 1e21) {
+			return strconv.FormatFloat(float64(v), 'f', -1, 64)
+		}
+	}
+	return v.String()
+}
@@ -0,0 +1,406 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// namingModule implements the "naming" module for building and validating resource names.
+var namingModule = &starlarkstruct.Module{
+	Name: "naming",
+	Members: starlark.StringDict{
+		"build":          starlark.NewBuiltin("naming.build", namingBuild),
+		"sanitize":       starlark.NewBuiltin("naming.sanitize", namingSanitize),
+		"validate":       starlark.NewBuiltin("naming.validate", namingValidate),
+		"rule":           starlark.NewBuiltin("naming.rule", namingRuleFn),
+		"resource_types": starlark.NewBuiltin("naming.resource_types", namingResourceTypes),
+	},
+}
+
+// namingRule describes the names a resource type accepts. chars, start and end are bodies of
+// regular expression character classes, such as "a-z0-9-".
+type namingRule struct {
+	minLength int
+	maxLength int
+	chars     string
+	lowercase bool   // upper-case letters are converted to lower case before validation
+	separator string // default separator between components
+	start     string // characters the name may start with; empty means any allowed character
+	end       string // characters the name may end with; empty means any allowed character
+	noRepeat  string // characters that must not appear twice in a row
+}
+
+const (
+	namingAlnum      = "a-zA-Z0-9"
+	namingLowerAlnum = "a-z0-9"
+)
+
+// namingRules are the built-in rules, keyed by Terraform resource type. They follow the naming
+// restrictions documented by each cloud.
+var namingRules = map[string]namingRule{
+	// Azure
+	"azurerm_resource_group":             {minLength: 1, maxLength: 90, chars: `a-zA-Z0-9._()\-`, separator: "-", end: `a-zA-Z0-9_()\-`},
+	"azurerm_storage_account":            {minLength: 3, maxLength: 24, chars: namingLowerAlnum, lowercase: true},
+	"azurerm_key_vault":                  {minLength: 3, maxLength: 24, chars: `a-zA-Z0-9\-`, separator: "-", start: "a-zA-Z", end: namingAlnum, noRepeat: "-"},
+	"azurerm_virtual_network":            {minLength: 2, maxLength: 64, chars: `a-zA-Z0-9._\-`, separator: "-", start: namingAlnum, end: "a-zA-Z0-9_"},
+	"azurerm_subnet":                     {minLength: 1, maxLength: 80, chars: `a-zA-Z0-9._\-`, separator: "-", start: namingAlnum, end: "a-zA-Z0-9_"},
+	"azurerm_network_security_group":     {minLength: 1, maxLength: 80, chars: `a-zA-Z0-9._\-`, separator: "-", start: namingAlnum, end: "a-zA-Z0-9_"},
+	"azurerm_public_ip":                  {minLength: 1, maxLength: 80, chars: `a-zA-Z0-9._\-`, separator: "-", start: namingAlnum, end: "a-zA-Z0-9_"},
+	"azurerm_linux_virtual_machine":      {minLength: 1, maxLength: 64, chars: `a-zA-Z0-9.\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+	"azurerm_windows_virtual_machine":    {minLength: 1, maxLength: 15, chars: `a-zA-Z0-9\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+	"azurerm_kubernetes_cluster":         {minLength: 1, maxLength: 63, chars: `a-zA-Z0-9_\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+	"azurerm_container_registry":         {minLength: 5, maxLength: 50, chars: namingAlnum},
+	"azurerm_log_analytics_workspace":    {minLength: 4, maxLength: 63, chars: `a-zA-Z0-9\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+	"azurerm_cosmosdb_account":           {minLength: 3, maxLength: 44, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: namingLowerAlnum, end: namingLowerAlnum},
+	"azurerm_mssql_server":               {minLength: 1, maxLength: 63, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: namingLowerAlnum, end: namingLowerAlnum},
+	"azurerm_postgresql_flexible_server": {minLength: 3, maxLength: 63, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: namingLowerAlnum, end: namingLowerAlnum},
+	"azurerm_linux_web_app":              {minLength: 2, maxLength: 60, chars: `a-zA-Z0-9\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+	"azurerm_windows_web_app":            {minLength: 2, maxLength: 60, chars: `a-zA-Z0-9\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+
+	// AWS
+	"aws_s3_bucket":       {minLength: 3, maxLength: 63, chars: `a-z0-9.\-`, lowercase: true, separator: "-", start: namingLowerAlnum, end: namingLowerAlnum, noRepeat: "."},
+	"aws_iam_role":        {minLength: 1, maxLength: 64, chars: `a-zA-Z0-9+=,.@_\-`, separator: "-"},
+	"aws_iam_user":        {minLength: 1, maxLength: 64, chars: `a-zA-Z0-9+=,.@_\-`, separator: "-"},
+	"aws_lambda_function": {minLength: 1, maxLength: 64, chars: `a-zA-Z0-9_\-`, separator: "-"},
+	"aws_sqs_queue":       {minLength: 1, maxLength: 80, chars: `a-zA-Z0-9_\-`, separator: "-"},
+	"aws_db_instance":     {minLength: 1, maxLength: 63, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum, noRepeat: "-"},
+	"aws_lb":              {minLength: 1, maxLength: 32, chars: `a-zA-Z0-9\-`, separator: "-", start: namingAlnum, end: namingAlnum},
+
+	// Google Cloud
+	"google_storage_bucket":        {minLength: 3, maxLength: 63, chars: `a-z0-9._\-`, lowercase: true, separator: "-", start: namingLowerAlnum, end: namingLowerAlnum},
+	"google_compute_instance":      {minLength: 1, maxLength: 63, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+	"google_compute_network":       {minLength: 1, maxLength: 63, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+	"google_compute_subnetwork":    {minLength: 1, maxLength: 63, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+	"google_project":               {minLength: 6, maxLength: 30, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+	"google_service_account":       {minLength: 6, maxLength: 30, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+	"google_container_cluster":     {minLength: 1, maxLength: 40, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+	"google_sql_database_instance": {minLength: 1, maxLength: 98, chars: `a-z0-9\-`, lowercase: true, separator: "-", start: "a-z", end: namingLowerAlnum},
+}
+
+// compiledNamingRule holds the regular expressions of a rule.
+type compiledNamingRule struct {
+	namingRule
+	invalid *regexp.Regexp
+	start   *regexp.Regexp
+	end     *regexp.Regexp
+}
+
+func (r namingRule) compile() (*compiledNamingRule, error) {
+	c := &compiledNamingRule{namingRule: r}
+	var err error
+	if c.invalid, err = regexp.Compile("[^" + r.chars + "]"); err != nil {
+		return nil, fmt.Errorf("chars: %s", err)
+	}
+	if r.start != "" {
+		if c.start, err = regexp.Compile("^[" + r.start + "]"); err != nil {
+			return nil, fmt.Errorf("start: %s", err)
+		}
+	}
+	if r.end != "" {
+		if c.end, err = regexp.Compile("[" + r.end + "]$"); err != nil {
+			return nil, fmt.Errorf("end: %s", err)
+		}
+	}
+	return c, nil
+}
+
+func (r namingRule) toDict() *starlark.Dict {
+	d := starlark.NewDict(8)
+	_ = d.SetKey(starlark.String("min_length"), starlark.MakeInt(r.minLength))
+	_ = d.SetKey(starlark.String("max_length"), starlark.MakeInt(r.maxLength))
+	_ = d.SetKey(starlark.String("chars"), starlark.String(r.chars))
+	_ = d.SetKey(starlark.String("lowercase"), starlark.Bool(r.lowercase))
+	_ = d.SetKey(starlark.String("separator"), starlark.String(r.separator))
+	_ = d.SetKey(starlark.String("start"), starlark.String(r.start))
+	_ = d.SetKey(starlark.String("end"), starlark.String(r.end))
+	_ = d.SetKey(starlark.String("no_repeat"), starlark.String(r.noRepeat))
+	return d
+}
+
+// unpackNamingRule resolves a rule argument, which is either a resource type from the built-in table
+// or a dict with the same keys as naming.rule returns.
+func unpackNamingRule(v starlark.Value) (*compiledNamingRule, error) {
+	switch v := v.(type) {
+	case starlark.String:
+		r, ok := namingRules[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("unknown resource type %q, pass a rule dict instead", string(v))
+		}
+		return r.compile()
+	case starlark.Mapping:
+		r := namingRule{minLength: 1}
+		for _, f := range []struct {
+			key string
+			dst *int
+		}{{"min_length", &r.minLength}, {"max_length", &r.maxLength}} {
+			x, found, err := v.Get(starlark.String(f.key))
+			if err != nil {
+				return nil, err
+			}
+			if !found || x == starlark.None {
+				continue
+			}
+			if err := starlark.AsInt(x, f.dst); err != nil {
+				return nil, fmt.Errorf("%s: %s", f.key, err)
+			}
+		}
+		for _, f := range []idFieldTarget{
+			{"chars", &r.chars},
+			{"separator", &r.separator},
+			{"start", &r.start},
+			{"end", &r.end},
+			{"no_repeat", &r.noRepeat},
+		} {
+			var err error
+			if *f.dst, err = idField(v, f.key); err != nil {
+				return nil, err
+			}
+		}
+		if x, found, err := v.Get(starlark.String("lowercase")); err != nil {
+			return nil, err
+		} else if found {
+			r.lowercase = bool(x.Truth())
+		}
+		switch {
+		case r.chars == "":
+			return nil, fmt.Errorf("rule must set chars")
+		case r.maxLength <= 0:
+			return nil, fmt.Errorf("rule must set a positive max_length")
+		case r.minLength < 0 || r.minLength > r.maxLength:
+			return nil, fmt.Errorf("rule min_length must be between 0 and max_length")
+		}
+		return r.compile()
+	default:
+		return nil, fmt.Errorf("got %s, want resource type or rule dict", v.Type())
+	}
+}
+
+// sanitize cleans name and trims characters that it may not start or end with.
+func (r *compiledNamingRule) sanitize(name string) string {
+	return r.trim(r.clean(name))
+}
+
+// clean converts name to lower case if the rule requires it, removes characters the rule does not
+// allow and collapses repeated characters. Unlike sanitize it keeps the ends of name as they are.
+func (r *compiledNamingRule) clean(name string) string {
+	if r.lowercase {
+		name = strings.ToLower(name)
+	}
+	name = r.invalid.ReplaceAllString(name, "")
+	for _, c := range r.noRepeat {
+		double := string(c) + string(c)
+		for strings.Contains(name, double) {
+			name = strings.ReplaceAll(name, double, string(c))
+		}
+	}
+	return name
+}
+
+// trim removes characters from the ends of name that the rule does not allow there.
+func (r *compiledNamingRule) trim(name string) string {
+	for r.start != nil && name != "" && !r.start.MatchString(name) {
+		_, size := utf8.DecodeRuneInString(name)
+		name = name[size:]
+	}
+	for r.end != nil && name != "" && !r.end.MatchString(name) {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// hashSuffix returns n characters derived from a SHA-256 hash of name. It uses hex digits when the
+// rule allows them, and otherwise encodes the hash with the letters and digits the rule allows.
+func (r *compiledNamingRule) hashSuffix(name string, n int) (string, error) {
+	sum := sha256.Sum256([]byte(name))
+	if !r.invalid.MatchString("0123456789abcdef") {
+		return hex.EncodeToString(sum[:])[:n], nil
+	}
+	var alphabet []byte
+	for _, c := range []byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		if !r.invalid.Match([]byte{c}) && (!r.lowercase || c < 'A' || c > 'Z') {
+			alphabet = append(alphabet, c)
+		}
+	}
+	if len(alphabet) < 2 {
+		return "", fmt.Errorf("the rule allows fewer than two ASCII letters or digits, so a long name cannot be shortened with a hash")
+	}
+	x := new(big.Int).SetBytes(sum[:])
+	base := big.NewInt(int64(len(alphabet)))
+	digit := new(big.Int)
+	suffix := make([]byte, n)
+	for i := range suffix {
+		x.DivMod(x, base, digit)
+		suffix[i] = alphabet[digit.Int64()]
+	}
+	return string(suffix), nil
+}
+
+// violations returns the reasons name breaks the rule, or nothing if it is valid.
+func (r *compiledNamingRule) violations(name string) []string {
+	var reasons []string
+	if n := len([]rune(name)); n < r.minLength {
+		reasons = append(reasons, fmt.Sprintf("must be at least %d characters, got %d", r.minLength, n))
+	} else if n > r.maxLength {
+		reasons = append(reasons, fmt.Sprintf("must be at most %d characters, got %d", r.maxLength, n))
+	}
+	if invalid := r.invalid.FindAllString(name, -1); len(invalid) > 0 {
+		seen := make(map[string]bool)
+		var unique []string
+		for _, s := range invalid {
+			if !seen[s] {
+				seen[s] = true
+				unique = append(unique, s)
+			}
+		}
+		reason := fmt.Sprintf("contains invalid characters %q, allowed are [%s]", strings.Join(unique, ""), r.chars)
+		if r.lowercase && strings.ToLower(name) != name {
+			reason = "must be lower case; " + reason
+		}
+		reasons = append(reasons, reason)
+	}
+	if name != "" && r.start != nil && !r.start.MatchString(name) {
+		reasons = append(reasons, fmt.Sprintf("must start with one of [%s]", r.namingRule.start))
+	}
+	if name != "" && r.end != nil && !r.end.MatchString(name) {
+		reasons = append(reasons, fmt.Sprintf("must end with one of [%s]", r.namingRule.end))
+	}
+	for _, c := range r.noRepeat {
+		if strings.Contains(name, string(c)+string(c)) {
+			reasons = append(reasons, fmt.Sprintf("must not contain consecutive %q", string(c)))
+		}
+	}
+	return reasons
+}
+
+// namingBuild joins components with the rule's separator and sanitizes the result. A name longer than
+// max_length is truncated and ends with a hash of the full name, so different long names stay
+// distinct and the same inputs always give the same name.
+func namingBuild(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ruleValue, components starlark.Value
+	var separator starlark.Value = starlark.None
+	hashLength := 6
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rule", &ruleValue, "components", &components, "separator?", &separator, "hash_length?", &hashLength); err != nil {
+		return nil, err
+	}
+	r, err := unpackNamingRule(ruleValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	sep := r.separator
+	if separator != starlark.None {
+		s, ok := starlark.AsString(separator)
+		if !ok {
+			return nil, fmt.Errorf("%s: separator must be a string, got %s", b.Name(), separator.Type())
+		}
+		sep = r.invalid.ReplaceAllString(s, "")
+	}
+	if hashLength < 1 || hashLength > 64 {
+		return nil, fmt.Errorf("%s: hash_length must be between 1 and 64", b.Name())
+	}
+
+	values, err := iterableValues(components)
+	if err != nil {
+		return nil, fmt.Errorf("%s: components: %s", b.Name(), err)
+	}
+	var parts []string
+	for _, v := range values {
+		if v == starlark.None {
+			continue
+		}
+		if s := r.clean(displayString(v)); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	name := r.sanitize(strings.Join(parts, sep))
+
+	if utf8.RuneCountInString(name) > r.maxLength {
+		suffix, err := r.hashSuffix(name, hashLength)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+		keep := r.maxLength - hashLength - utf8.RuneCountInString(sep)
+		if keep < 1 {
+			return nil, fmt.Errorf("%s: max_length %d leaves no room for a %d character hash", b.Name(), r.maxLength, hashLength)
+		}
+		prefix := strings.TrimSuffix(r.trim(string([]rune(name)[:keep])), sep)
+		name = prefix + sep + suffix
+	}
+
+	if reasons := r.violations(name); len(reasons) > 0 {
+		return nil, fmt.Errorf("%s: %q is not a valid name: %s", b.Name(), name, strings.Join(reasons, "; "))
+	}
+	return starlark.String(name), nil
+}
+
+func namingSanitize(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ruleValue starlark.Value
+	var name string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rule", &ruleValue, "name", &name); err != nil {
+		return nil, err
+	}
+	r, err := unpackNamingRule(ruleValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(r.sanitize(name)), nil
+}
+
+// namingValidate returns the reasons name breaks the rule. An empty list means the name is valid.
+func namingValidate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ruleValue starlark.Value
+	var name string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rule", &ruleValue, "name", &name); err != nil {
+		return nil, err
+	}
+	r, err := unpackNamingRule(ruleValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	reasons := r.violations(name)
+	result := make([]starlark.Value, len(reasons))
+	for i, reason := range reasons {
+		result[i] = starlark.String(reason)
+	}
+	return starlark.NewList(result), nil
+}
+
+func namingRuleFn(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var resourceType string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "resource_type", &resourceType); err != nil {
+		return nil, err
+	}
+	r, ok := namingRules[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown resource type %q", b.Name(), resourceType)
+	}
+	return r.toDict(), nil
+}
+
+func namingResourceTypes(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(namingRules))
+	for t := range namingRules {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	result := make([]starlark.Value, len(types))
+	for i, t := range types {
+		result[i] = starlark.String(t)
+	}
+	return starlark.NewList(result), nil
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccNamingModule_build(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "storage_account" {
+					value = provider::starlark::eval("result = naming.build('azurerm_storage_account', ['st', app, env, 'weu'])", {
+						app = "My-App"
+						env = "prod"
+					})
+				}
+				output "truncated" {
+					value = provider::starlark::eval("result = naming.build('azurerm_storage_account', ['st', 'contoso-billing-platform', 'prod', 'westeurope'])", {})
+				}
+				output "key_vault" {
+					value = provider::starlark::eval("result = naming.build('azurerm_key_vault', ['kv', 'contoso--billing', 'prod', 'westeurope', '001'])", {})
+				}
+				output "letters_only" {
+					value = provider::starlark::eval("result = naming.build({'chars': 'a-z', 'max_length': 12}, ['verylongname', 'another'])", {})
+				}
+				output "non_ascii" {
+					value = provider::starlark::eval("result = naming.build({'chars': 'a-zäöü-', 'max_length': 12, 'separator': '-'}, ['größe', 'übermäßig', 'öl'])", {})
+				}
+				output "number_component" {
+					value = provider::starlark::eval("result = [naming.build('azurerm_storage_account', ['app', 'prod', n]), naming.build('azurerm_key_vault', ['kv', 'app', n])]", {
+						n = 1
+					})
+				}
+				output "custom_rule" {
+					value = provider::starlark::eval("result = naming.build({'chars': 'a-z0-9', 'max_length': 8, 'lowercase': True}, ['Hello', 'World', 'again'])", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("storage_account", "stmyappprodweu"),
+					resource.TestCheckOutput("truncated", "stcontosobillingpl3716f7"),
+					resource.TestCheckOutput("key_vault", "kv-contoso-billin-777a77"),
+					resource.TestCheckOutput("custom_rule", "he5a8ead"),
+					NewTestCheckOutput("number_component", []interface{}{"appprod1", "kv-app-1"}),
+					resource.TestCheckOutput("letters_only", "verylobxamne"),
+					resource.TestCheckOutput("non_ascii", "gröe-yvpmig"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNamingModule_sanitizeAndValidate(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "sanitize" {
+					value = provider::starlark::eval("result = naming.sanitize('azurerm_key_vault', '-My__Vault--01-')", {})
+				}
+				output "valid" {
+					value = provider::starlark::eval("result = naming.validate('azurerm_key_vault', 'kv-app-prod')", {})
+				}
+				output "invalid" {
+					value = provider::starlark::eval("result = naming.validate('azurerm_key_vault', '1kv--x-')", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("sanitize", "MyVault-01"),
+					NewTestCheckOutput("valid", []interface{}{}),
+					NewTestCheckOutput("invalid", []interface{}{
+						"must start with one of [a-zA-Z]",
+						"must end with one of [a-zA-Z0-9]",
+						`must not contain consecutive "-"`,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNamingModule_tooShort(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = naming.build('azurerm_storage_account', ['s', '-'])", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`must be at least 3 characters`),
+			},
+		},
+	})
+}
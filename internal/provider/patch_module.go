@@ -0,0 +1,580 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// patchModule implements the "patch" module: RFC 6902 JSON Patch, RFC 7386 JSON Merge Patch,
+// and a structural diff. Paths are JSON Pointers (RFC 6901), such as "/tags/env" or "/rules/0".
+var patchModule = &starlarkstruct.Module{
+	Name: "patch",
+	Members: starlark.StringDict{
+		"apply":                starlark.NewBuiltin("patch.apply", patchApply),
+		"generate":             starlark.NewBuiltin("patch.generate", patchGenerate),
+		"merge_patch":          starlark.NewBuiltin("patch.merge_patch", patchMergePatch),
+		"generate_merge_patch": starlark.NewBuiltin("patch.generate_merge_patch", patchGenerateMergePatch),
+		"diff":                 starlark.NewBuiltin("patch.diff", patchDiff),
+	},
+}
+
+// JSON Pointer
+
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func formatPointer(tokens []string) string {
+	var buf strings.Builder
+	for _, t := range tokens {
+		buf.WriteByte('/')
+		buf.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return buf.String()
+}
+
+// pointerIndex parses a JSON Pointer array index. "-" refers to the end of the list and is only
+// allowed when allowEnd is set.
+func pointerIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+func pointerGet(doc starlark.Value, tokens []string) (starlark.Value, error) {
+	v := doc
+	for i, t := range tokens {
+		switch x := v.(type) {
+		case *starlark.Dict:
+			child, found, err := x.Get(starlark.String(t))
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, fmt.Errorf("path %s does not exist", formatPointer(tokens[:i+1]))
+			}
+			v = child
+		case *starlark.List:
+			idx, err := pointerIndex(t, x.Len(), false)
+			if err != nil {
+				return nil, fmt.Errorf("path %s: %s", formatPointer(tokens[:i+1]), err)
+			}
+			v = x.Index(idx)
+		default:
+			return nil, fmt.Errorf("path %s: cannot index %s", formatPointer(tokens[:i+1]), v.Type())
+		}
+	}
+	return v, nil
+}
+
+// pointerAdd adds value at tokens within doc, which must be a copy owned by the caller, and
+// returns the new document. Adding to a list inserts; adding to a dict sets.
+func pointerAdd(doc starlark.Value, tokens []string, value starlark.Value) (starlark.Value, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent, err := pointerGet(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+	switch x := parent.(type) {
+	case *starlark.Dict:
+		return doc, x.SetKey(starlark.String(last), value)
+	case *starlark.List:
+		idx, err := pointerIndex(last, x.Len(), true)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %s", formatPointer(tokens), err)
+		}
+		return doc, listInsert(x, idx, value)
+	default:
+		return nil, fmt.Errorf("path %s: cannot add to %s", formatPointer(tokens), parent.Type())
+	}
+}
+
+// pointerRemove removes the value at tokens within doc, which must be a copy owned by the caller,
+// and returns the removed value.
+func pointerRemove(doc starlark.Value, tokens []string) (starlark.Value, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	parent, err := pointerGet(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+	switch x := parent.(type) {
+	case *starlark.Dict:
+		removed, found, err := x.Delete(starlark.String(last))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("path %s does not exist", formatPointer(tokens))
+		}
+		return removed, nil
+	case *starlark.List:
+		idx, err := pointerIndex(last, x.Len(), false)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %s", formatPointer(tokens), err)
+		}
+		return listRemove(x, idx)
+	default:
+		return nil, fmt.Errorf("path %s: cannot remove from %s", formatPointer(tokens), parent.Type())
+	}
+}
+
+func listInsert(l *starlark.List, i int, v starlark.Value) error {
+	if err := l.Append(starlark.None); err != nil {
+		return err
+	}
+	for j := l.Len() - 1; j > i; j-- {
+		if err := l.SetIndex(j, l.Index(j-1)); err != nil {
+			return err
+		}
+	}
+	return l.SetIndex(i, v)
+}
+
+func listRemove(l *starlark.List, i int) (starlark.Value, error) {
+	removed := l.Index(i)
+	elems := make([]starlark.Value, 0, l.Len()-1)
+	for j := 0; j < l.Len(); j++ {
+		if j != i {
+			elems = append(elems, l.Index(j))
+		}
+	}
+	if err := l.Clear(); err != nil {
+		return nil, err
+	}
+	for _, e := range elems {
+		if err := l.Append(e); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+// RFC 6902
+
+// patchApply applies a JSON Patch to a copy of doc. Operations are applied in order, and the whole
+// patch fails if any operation fails, including a failed "test".
+func patchApply(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var doc starlark.Value
+	var ops *starlark.List
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "doc", &doc, "patch", &ops); err != nil {
+		return nil, err
+	}
+	result, err := deepCopy(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	for i := 0; i < ops.Len(); i++ {
+		if result, err = applyPatchOperation(result, ops.Index(i)); err != nil {
+			return nil, fmt.Errorf("%s: operation %d: %s", b.Name(), i, err)
+		}
+	}
+	return result, nil
+}
+
+func applyPatchOperation(doc starlark.Value, op starlark.Value) (starlark.Value, error) {
+	d, ok := op.(*starlark.Dict)
+	if !ok {
+		return nil, fmt.Errorf("got %s, want dict", op.Type())
+	}
+	field := func(name string) (starlark.Value, bool) {
+		v, found, _ := d.Get(starlark.String(name))
+		return v, found
+	}
+	pointerField := func(name string) ([]string, error) {
+		v, found := field(name)
+		if !found {
+			return nil, fmt.Errorf("missing %q", name)
+		}
+		s, ok := starlark.AsString(v)
+		if !ok {
+			return nil, fmt.Errorf("%q must be a string, got %s", name, v.Type())
+		}
+		return parsePointer(s)
+	}
+
+	opName, found := field("op")
+	if !found {
+		return nil, fmt.Errorf(`missing "op"`)
+	}
+	path, err := pointerField("path")
+	if err != nil {
+		return nil, err
+	}
+	value, hasValue := field("value")
+
+	switch opName {
+	case starlark.String("add"), starlark.String("replace"), starlark.String("test"):
+		if !hasValue {
+			return nil, fmt.Errorf(`missing "value"`)
+		}
+	}
+
+	if hasValue {
+		if value, err = deepCopy(value); err != nil {
+			return nil, err
+		}
+	}
+
+	switch opName {
+	case starlark.String("add"):
+		return pointerAdd(doc, path, value)
+	case starlark.String("remove"):
+		_, err := pointerRemove(doc, path)
+		return doc, err
+	case starlark.String("replace"):
+		if len(path) == 0 {
+			return value, nil
+		}
+		if _, err := pointerRemove(doc, path); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	case starlark.String("move"), starlark.String("copy"):
+		from, err := pointerField("from")
+		if err != nil {
+			return nil, err
+		}
+		var v starlark.Value
+		if opName == starlark.String("move") {
+			if len(path) > len(from) && formatPointer(path[:len(from)]) == formatPointer(from) {
+				return nil, fmt.Errorf("cannot move %s into itself", formatPointer(from))
+			}
+			if len(from) == 0 {
+				return doc, nil
+			}
+			v, err = pointerRemove(doc, from)
+		} else {
+			if v, err = pointerGet(doc, from); err == nil {
+				v, err = deepCopy(v)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, v)
+	case starlark.String("test"):
+		actual, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		eq, err := starlark.EqualDepth(actual, value, maxPatchDepth)
+		if err != nil {
+			return nil, err
+		}
+		if !eq {
+			return nil, fmt.Errorf("test failed: %s is %s, want %s", formatPointer(path), actual, value)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown op %s", opName)
+	}
+}
+
+// maxPatchDepth bounds the recursion when comparing values.
+const maxPatchDepth = 1000
+
+// patchGenerate returns a JSON Patch that turns a into b.
+func patchGenerate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var from, to starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &from, "b", &to); err != nil {
+		return nil, err
+	}
+	changes, err := diffValues(nil, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	ops := make([]starlark.Value, 0, len(changes))
+	for _, c := range changes {
+		op := starlark.NewDict(3)
+		_ = op.SetKey(starlark.String("op"), starlark.String(c.op))
+		_ = op.SetKey(starlark.String("path"), starlark.String(formatPointer(c.path)))
+		if c.op != "remove" {
+			v, err := deepCopy(c.new)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", b.Name(), err)
+			}
+			_ = op.SetKey(starlark.String("value"), v)
+		}
+		ops = append(ops, op)
+	}
+	return starlark.NewList(ops), nil
+}
+
+// Structural diff
+
+type valueChange struct {
+	op       string // "add", "remove" or "replace"
+	path     []string
+	old, new starlark.Value
+}
+
+// diffValues compares a and b recursively. Dict keys are visited in sorted order. Lists are
+// compared position by position, with extra elements added at, or removed from, the end;
+// removals are listed from the last index so the changes can be applied in order.
+func diffValues(path []string, a, b starlark.Value) ([]valueChange, error) {
+	at := func(token string) []string {
+		return append(path[:len(path):len(path)], token)
+	}
+
+	if da, ok := a.(*starlark.Dict); ok {
+		if db, ok := b.(*starlark.Dict); ok {
+			keys := map[string]bool{}
+			for _, m := range []*starlark.Dict{da, db} {
+				for _, k := range m.Keys() {
+					s, ok := starlark.AsString(k)
+					if !ok {
+						return nil, fmt.Errorf("dict keys must be strings, got %s", k.Type())
+					}
+					keys[s] = true
+				}
+			}
+			sorted := make([]string, 0, len(keys))
+			for k := range keys {
+				sorted = append(sorted, k)
+			}
+			sort.Strings(sorted)
+
+			var changes []valueChange
+			for _, k := range sorted {
+				va, inA, _ := da.Get(starlark.String(k))
+				vb, inB, _ := db.Get(starlark.String(k))
+				switch {
+				case !inB:
+					changes = append(changes, valueChange{op: "remove", path: at(k), old: va, new: starlark.None})
+				case !inA:
+					changes = append(changes, valueChange{op: "add", path: at(k), old: starlark.None, new: vb})
+				default:
+					sub, err := diffValues(at(k), va, vb)
+					if err != nil {
+						return nil, err
+					}
+					changes = append(changes, sub...)
+				}
+			}
+			return changes, nil
+		}
+	}
+
+	if la, ok := a.(*starlark.List); ok {
+		if lb, ok := b.(*starlark.List); ok {
+			var changes []valueChange
+			common := la.Len()
+			if lb.Len() < common {
+				common = lb.Len()
+			}
+			for i := 0; i < common; i++ {
+				sub, err := diffValues(at(strconv.Itoa(i)), la.Index(i), lb.Index(i))
+				if err != nil {
+					return nil, err
+				}
+				changes = append(changes, sub...)
+			}
+			for i := la.Len() - 1; i >= common; i-- {
+				changes = append(changes, valueChange{op: "remove", path: at(strconv.Itoa(i)), old: la.Index(i), new: starlark.None})
+			}
+			for i := common; i < lb.Len(); i++ {
+				changes = append(changes, valueChange{op: "add", path: at(strconv.Itoa(i)), old: starlark.None, new: lb.Index(i)})
+			}
+			return changes, nil
+		}
+	}
+
+	eq, err := starlark.EqualDepth(a, b, maxPatchDepth)
+	if err != nil {
+		return nil, err
+	}
+	if eq {
+		return nil, nil
+	}
+	return []valueChange{{op: "replace", path: path, old: a, new: b}}, nil
+}
+
+// patchDiff returns the differences between a and b as a list of {op, path, old, new} dicts.
+// old is None for additions, and new is None for removals.
+func patchDiff(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var from, to starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &from, "b", &to); err != nil {
+		return nil, err
+	}
+	changes, err := diffValues(nil, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	entries := make([]starlark.Value, 0, len(changes))
+	for _, c := range changes {
+		entry := starlark.NewDict(4)
+		_ = entry.SetKey(starlark.String("op"), starlark.String(c.op))
+		_ = entry.SetKey(starlark.String("path"), starlark.String(formatPointer(c.path)))
+		for _, field := range []struct {
+			name  string
+			value starlark.Value
+		}{{"old", c.old}, {"new", c.new}} {
+			v, err := deepCopy(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", b.Name(), err)
+			}
+			_ = entry.SetKey(starlark.String(field.name), v)
+		}
+		entries = append(entries, entry)
+	}
+	return starlark.NewList(entries), nil
+}
+
+// RFC 7386
+
+func patchMergePatch(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var target, patch starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "target", &target, "patch", &patch); err != nil {
+		return nil, err
+	}
+	result, err := mergePatch(target, patch, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return result, nil
+}
+
+// mergePatch implements the MergePatch algorithm from RFC 7386: dicts are merged recursively,
+// None removes a key, and any other value, including a list, replaces the target.
+// path holds the patch dicts that contain patch.
+func mergePatch(target, patch starlark.Value, path []starlark.Value) (starlark.Value, error) {
+	p, ok := patch.(*starlark.Dict)
+	if !ok {
+		return deepCopy(patch)
+	}
+	if err := checkCycle(p, path); err != nil {
+		return nil, err
+	}
+	copied, err := deepCopy(target)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := copied.(*starlark.Dict)
+	if !ok {
+		result = starlark.NewDict(p.Len())
+	}
+	for _, item := range p.Items() {
+		if item[1] == starlark.None {
+			_, _, _ = result.Delete(item[0])
+			continue
+		}
+		existing, found, _ := result.Get(item[0])
+		if !found {
+			existing = starlark.None
+		}
+		merged, err := mergePatch(existing, item[1], append(path, p))
+		if err != nil {
+			return nil, err
+		}
+		_ = result.SetKey(item[0], merged)
+	}
+	return result, nil
+}
+
+// patchGenerateMergePatch returns a merge patch that turns a into b. Since None means "remove" in a
+// merge patch, it fails if b contains None values that are not already in a.
+func patchGenerateMergePatch(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var from, to starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &from, "b", &to); err != nil {
+		return nil, err
+	}
+	patch, err := generateMergePatch(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return patch, nil
+}
+
+func generateMergePatch(a, b starlark.Value) (starlark.Value, error) {
+	da, okA := a.(*starlark.Dict)
+	db, okB := b.(*starlark.Dict)
+	if !okA || !okB {
+		if containsNone(b) {
+			return nil, fmt.Errorf("cannot express a None value in a merge patch")
+		}
+		return deepCopy(b)
+	}
+
+	patch := starlark.NewDict(0)
+	for _, item := range da.Items() {
+		if _, found, _ := db.Get(item[0]); !found {
+			_ = patch.SetKey(item[0], starlark.None)
+		}
+	}
+	for _, item := range db.Items() {
+		old, found, _ := da.Get(item[0])
+		if found {
+			eq, err := starlark.EqualDepth(old, item[1], maxPatchDepth)
+			if err != nil {
+				return nil, err
+			}
+			if eq {
+				continue
+			}
+		}
+		if !found {
+			old = starlark.None
+		}
+		sub, err := generateMergePatch(old, item[1])
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %s", item[0], err)
+		}
+		_ = patch.SetKey(item[0], sub)
+	}
+	return patch, nil
+}
+
+// containsNone reports whether v is None or a dict with None values at any depth. None inside a
+// list is fine, since lists replace the target as a whole.
+func containsNone(v starlark.Value) bool {
+	switch x := v.(type) {
+	case starlark.NoneType:
+		return true
+	case *starlark.Dict:
+		for _, item := range x.Items() {
+			if containsNone(item[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
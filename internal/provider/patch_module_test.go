@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccPatchModule_jsonPatch(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "apply" {
+					value = provider::starlark::eval(
+						"result = patch.apply(doc, ops)",
+						{
+							doc = { name = "web", ports = [80], tags = { env = "dev" } }
+							ops = [
+								{ op = "test", path = "/name", value = "web" },
+								{ op = "add", path = "/ports/-", value = 443 },
+								{ op = "replace", path = "/tags/env", value = "prod" },
+								{ op = "copy", from = "/tags/env", path = "/environment" },
+								{ op = "remove", path = "/name" },
+							]
+						}
+					)
+				}
+				output "generate" {
+					value = provider::starlark::eval(
+						"result = patch.generate({'a': 1, 'b': [1, 2], 'c': 'x'}, {'a': 2, 'b': [1], 'd/e': True})",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("apply", map[string]interface{}{
+						"ports":       []interface{}{json.Number("80"), json.Number("443")},
+						"tags":        map[string]interface{}{"env": "prod"},
+						"environment": "prod",
+					}),
+					NewTestCheckOutput("generate", []interface{}{
+						map[string]interface{}{"op": "replace", "path": "/a", "value": json.Number("2")},
+						map[string]interface{}{"op": "remove", "path": "/b/1"},
+						map[string]interface{}{"op": "remove", "path": "/c"},
+						map[string]interface{}{"op": "add", "path": "/d~1e", "value": true},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPatchModule_mergePatch(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "merge_patch" {
+					value = provider::starlark::eval(
+						"result = patch.merge_patch({'a': 'b', 'c': {'d': 'e', 'f': 'g'}}, {'a': 'z', 'c': {'f': None}})",
+						{}
+					)
+				}
+				output "generate_merge_patch" {
+					value = provider::starlark::eval(
+						"result = patch.generate_merge_patch({'a': 1, 'b': {'c': 1, 'd': 2}}, {'a': 1, 'b': {'c': 2}})",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("merge_patch", map[string]interface{}{
+						"a": "z",
+						"c": map[string]interface{}{"d": "e"},
+					}),
+					NewTestCheckOutput("generate_merge_patch", map[string]interface{}{
+						"b": map[string]interface{}{"c": json.Number("2"), "d": nil},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPatchModule_diff(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval(
+						"result = patch.diff(desired, actual)",
+						{
+							desired = { sku = "Standard", tags = { env = "prod" } }
+							actual  = { sku = "Basic", tags = { env = "prod", owner = "ops" } }
+						}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("test", []interface{}{
+						map[string]interface{}{"op": "replace", "path": "/sku", "old": "Standard", "new": "Basic"},
+						map[string]interface{}{"op": "add", "path": "/tags/owner", "old": nil, "new": "ops"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPatchModule_testFailed(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = patch.apply({'a': 1}, [{'op': 'test', 'path': '/a', 'value': 2}])", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`test failed: /a is 1, want 2`),
+			},
+		},
+	})
+}
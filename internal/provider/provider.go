@@ -75,6 +75,12 @@ func (p *StarlarkProvider) DataSources(ctx context.Context) []func() datasource.
 func (p *StarlarkProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
 		NewEvalFunction,
+		NewRenderFunction,
+		NewMapFunction,
+		NewFilterFunction,
+		NewReduceFunction,
+		NewSortByFunction,
+		NewExportsFunction,
 	}
 }
 
@@ -0,0 +1,666 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// queryModule implements the "query" module, which selects values from nested data with JSONPath.
+//
+// Queries never fail because data is missing: a path that does not exist selects nothing.
+// Only a malformed query is an error.
+var queryModule = &starlarkstruct.Module{
+	Name: "query",
+	Members: starlark.StringDict{
+		"select": starlark.NewBuiltin("query.select", querySelect),
+		"first":  starlark.NewBuiltin("query.first", queryFirst),
+	},
+}
+
+func querySelect(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	var path string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "path", &path); err != nil {
+		return nil, err
+	}
+	q, err := parseJSONPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.NewList(q.eval(value, value)), nil
+}
+
+func queryFirst(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	var path string
+	var dflt starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "path", &path, "default?", &dflt); err != nil {
+		return nil, err
+	}
+	q, err := parseJSONPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if matches := q.eval(value, value); len(matches) > 0 {
+		return matches[0], nil
+	}
+	return dflt, nil
+}
+
+// Query evaluation
+
+// jpQuery is a parsed path. relative is set for "@" queries inside filters.
+type jpQuery struct {
+	relative bool
+	segments []jpSegment
+}
+
+// jpSegment is one step of a query: ".name", "[...]", or the recursive "..name" and "..[...]".
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+type jpSelector interface {
+	selectFrom(root, node starlark.Value, out []starlark.Value) []starlark.Value
+}
+
+func (q *jpQuery) eval(root, current starlark.Value) []starlark.Value {
+	nodes := []starlark.Value{root}
+	if q.relative {
+		nodes = []starlark.Value{current}
+	}
+	for _, seg := range q.segments {
+		if seg.descendant {
+			var expanded []starlark.Value
+			for _, n := range nodes {
+				expanded = appendDescendants(expanded, n)
+			}
+			nodes = expanded
+		}
+		var next []starlark.Value
+		for _, n := range nodes {
+			for _, sel := range seg.selectors {
+				next = sel.selectFrom(root, n, next)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// appendDescendants appends v and everything nested in it, in document order.
+func appendDescendants(out []starlark.Value, v starlark.Value) []starlark.Value {
+	out = append(out, v)
+	for _, child := range jpChildren(v) {
+		out = appendDescendants(out, child)
+	}
+	return out
+}
+
+func jpChildren(v starlark.Value) []starlark.Value {
+	switch x := v.(type) {
+	case *starlark.Dict:
+		children := make([]starlark.Value, 0, x.Len())
+		for _, item := range x.Items() {
+			children = append(children, item[1])
+		}
+		return children
+	case *starlark.List:
+		children := make([]starlark.Value, x.Len())
+		for i := range children {
+			children[i] = x.Index(i)
+		}
+		return children
+	}
+	return nil
+}
+
+type jpName string
+
+func (s jpName) selectFrom(_, node starlark.Value, out []starlark.Value) []starlark.Value {
+	if d, ok := node.(*starlark.Dict); ok {
+		if v, found, _ := d.Get(starlark.String(s)); found {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+type jpWildcard struct{}
+
+func (jpWildcard) selectFrom(_, node starlark.Value, out []starlark.Value) []starlark.Value {
+	return append(out, jpChildren(node)...)
+}
+
+type jpIndex int
+
+func (i jpIndex) selectFrom(_, node starlark.Value, out []starlark.Value) []starlark.Value {
+	if l, ok := node.(*starlark.List); ok {
+		idx := int(i)
+		if idx < 0 {
+			idx += l.Len()
+		}
+		if idx >= 0 && idx < l.Len() {
+			out = append(out, l.Index(idx))
+		}
+	}
+	return out
+}
+
+// jpSlice selects list elements like a Python slice. Missing bounds are nil.
+type jpSlice struct {
+	start, end *int
+	step       int
+}
+
+func (s jpSlice) selectFrom(_, node starlark.Value, out []starlark.Value) []starlark.Value {
+	l, ok := node.(*starlark.List)
+	if !ok || s.step == 0 {
+		return out
+	}
+	n := l.Len()
+	bound := func(p *int, dflt int) int {
+		if p == nil {
+			return dflt
+		}
+		i := *p
+		if i < 0 {
+			i += n
+		}
+		if s.step > 0 {
+			return max(0, min(i, n))
+		}
+		return max(-1, min(i, n-1))
+	}
+	if s.step > 0 {
+		for i := bound(s.start, 0); i < bound(s.end, n); i += s.step {
+			out = append(out, l.Index(i))
+		}
+	} else {
+		for i := bound(s.start, n-1); i > bound(s.end, -1); i += s.step {
+			out = append(out, l.Index(i))
+		}
+	}
+	return out
+}
+
+// jpFilter selects the children of a node for which the expression is true.
+type jpFilter struct {
+	expr jpExpr
+}
+
+func (f jpFilter) selectFrom(root, node starlark.Value, out []starlark.Value) []starlark.Value {
+	for _, child := range jpChildren(node) {
+		if f.expr.test(root, child) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// Filter expressions
+
+type jpExpr interface {
+	test(root, current starlark.Value) bool
+}
+
+type jpOr struct{ left, right jpExpr }
+
+func (e jpOr) test(root, current starlark.Value) bool {
+	return e.left.test(root, current) || e.right.test(root, current)
+}
+
+type jpAnd struct{ left, right jpExpr }
+
+func (e jpAnd) test(root, current starlark.Value) bool {
+	return e.left.test(root, current) && e.right.test(root, current)
+}
+
+type jpNot struct{ expr jpExpr }
+
+func (e jpNot) test(root, current starlark.Value) bool {
+	return !e.expr.test(root, current)
+}
+
+// jpExists is true when a query selects at least one value.
+type jpExists struct{ query *jpQuery }
+
+func (e jpExists) test(root, current starlark.Value) bool {
+	return len(e.query.eval(root, current)) > 0
+}
+
+// jpOperand is a literal, or a query whose first result is used.
+type jpOperand struct {
+	literal starlark.Value
+	query   *jpQuery
+}
+
+func (o jpOperand) value(root, current starlark.Value) (starlark.Value, bool) {
+	if o.query == nil {
+		return o.literal, true
+	}
+	matches := o.query.eval(root, current)
+	if len(matches) == 0 {
+		return nil, false
+	}
+	return matches[0], true
+}
+
+type jpCompare struct {
+	op          string
+	left, right jpOperand
+	pattern     *regexp.Regexp
+}
+
+func (e jpCompare) test(root, current starlark.Value) bool {
+	l, lok := e.left.value(root, current)
+	r, rok := e.right.value(root, current)
+	if !lok || !rok {
+		// A missing value only equals another missing value.
+		switch e.op {
+		case "==":
+			return !lok && !rok
+		case "!=":
+			return lok != rok
+		}
+		return false
+	}
+
+	switch e.op {
+	case "=~":
+		s, ok := l.(starlark.String)
+		return ok && e.pattern.MatchString(string(s))
+	case "==", "!=":
+		eq, err := starlark.Equal(l, r)
+		if err != nil {
+			eq = false
+		}
+		return eq == (e.op == "==")
+	}
+
+	// Ordering only applies to two numbers or two strings.
+	_, lnum := l.(starlark.Int)
+	_, lfloat := l.(starlark.Float)
+	_, rnum := r.(starlark.Int)
+	_, rfloat := r.(starlark.Float)
+	_, lstr := l.(starlark.String)
+	_, rstr := r.(starlark.String)
+	if !((lnum || lfloat) && (rnum || rfloat)) && !(lstr && rstr) {
+		return false
+	}
+	ops := map[string]syntax.Token{"<": syntax.LT, "<=": syntax.LE, ">": syntax.GT, ">=": syntax.GE}
+	ok, err := starlark.Compare(ops[e.op], l, r)
+	return err == nil && ok
+}
+
+// Parsing
+
+var jpNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?`)
+
+type jpParser struct {
+	src string
+	pos int
+}
+
+func (p *jpParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid query %q at offset %d: %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jpParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *jpParser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// parseJSONPath parses a query. The leading "$" is optional, so "items[0].name" is the same as "$.items[0].name".
+func parseJSONPath(src string) (*jpQuery, error) {
+	path := strings.TrimSpace(src)
+	switch {
+	case strings.HasPrefix(path, "$"):
+	case strings.HasPrefix(path, ".") || strings.HasPrefix(path, "["):
+		path = "$" + path
+	default:
+		path = "$." + path
+	}
+	p := &jpParser{src: path, pos: 1}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return &jpQuery{segments: segments}, nil
+}
+
+func (p *jpParser) parseSegments() ([]jpSegment, error) {
+	var segments []jpSegment
+	for p.pos < len(p.src) {
+		var seg jpSegment
+		switch {
+		case p.consume(".."):
+			seg.descendant = true
+			if p.pos < len(p.src) && p.src[p.pos] == '[' {
+				selectors, err := p.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = selectors
+			} else {
+				sel, err := p.parseDotSelector()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = []jpSelector{sel}
+			}
+		case p.consume("."):
+			sel, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []jpSelector{sel}
+		case p.src[p.pos] == '[':
+			selectors, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = selectors
+		default:
+			return segments, nil
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+func isJPNameChar(c byte) bool {
+	return c == '_' || c == '-' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *jpParser) parseDotSelector() (jpSelector, error) {
+	if p.consume("*") {
+		return jpWildcard{}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) && isJPNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected a name or *")
+	}
+	return jpName(p.src[start:p.pos]), nil
+}
+
+func (p *jpParser) parseBracket() ([]jpSelector, error) {
+	p.pos++ // [
+	var selectors []jpSelector
+	for {
+		p.skipSpace()
+		sel, err := p.parseBracketSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipSpace()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *jpParser) parseBracketSelector() (jpSelector, error) {
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unterminated [")
+	}
+	switch c := p.src[p.pos]; {
+	case c == '*':
+		p.pos++
+		return jpWildcard{}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return jpName(s), nil
+	case c == '?':
+		p.pos++
+		p.skipSpace()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return jpFilter{expr: expr}, nil
+	default:
+		return p.parseIndexOrSlice()
+	}
+}
+
+func (p *jpParser) parseInt() (*int, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.pos < len(p.src) && p.src[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return nil, p.errorf("invalid integer %q", p.src[start:p.pos])
+	}
+	p.skipSpace()
+	return &i, nil
+}
+
+func (p *jpParser) parseIndexOrSlice() (jpSelector, error) {
+	start, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(":") {
+		if start == nil {
+			return nil, p.errorf("expected a selector")
+		}
+		return jpIndex(*start), nil
+	}
+	end, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	s := jpSlice{start: start, end: end, step: 1}
+	if p.consume(":") {
+		step, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		if step != nil {
+			s.step = *step
+		}
+	}
+	return s, nil
+}
+
+func (p *jpParser) parseString() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+	var buf strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			return buf.String(), nil
+		case '\\':
+			if p.pos >= len(p.src) {
+				return "", p.errorf("unterminated string")
+			}
+			buf.WriteByte(p.src[p.pos])
+			p.pos++
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jpParser) parseOr() (jpExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = jpOr{left, right}
+	}
+}
+
+func (p *jpParser) parseAnd() (jpExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = jpAnd{left, right}
+	}
+}
+
+func (p *jpParser) parseUnary() (jpExpr, error) {
+	p.skipSpace()
+	if p.consume("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return jpNot{expr}, nil
+	}
+	if p.consume("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "=~", "<", ">"} {
+		if !p.consume(op) {
+			continue
+		}
+		p.skipSpace()
+		if op == "=~" {
+			if p.pos >= len(p.src) || (p.src[p.pos] != '\'' && p.src[p.pos] != '"') {
+				return nil, p.errorf("=~ must be followed by a quoted regular expression")
+			}
+			s, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			re, err := regexp.Compile(s)
+			if err != nil {
+				return nil, p.errorf("%s", err)
+			}
+			return jpCompare{op: op, left: left, pattern: re}, nil
+		}
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return jpCompare{op: op, left: left, right: right}, nil
+	}
+	if left.query == nil {
+		return nil, p.errorf("a literal must be compared with something")
+	}
+	return jpExists{left.query}, nil
+}
+
+func (p *jpParser) parseOperand() (jpOperand, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return jpOperand{}, p.errorf("unexpected end of filter")
+	}
+	rest := p.src[p.pos:]
+	switch c := rest[0]; {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return jpOperand{}, err
+		}
+		return jpOperand{query: &jpQuery{relative: c == '@', segments: segments}}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return jpOperand{}, err
+		}
+		return jpOperand{literal: starlark.String(s)}, nil
+	case strings.HasPrefix(rest, "true"):
+		p.pos += 4
+		return jpOperand{literal: starlark.True}, nil
+	case strings.HasPrefix(rest, "false"):
+		p.pos += 5
+		return jpOperand{literal: starlark.False}, nil
+	case strings.HasPrefix(rest, "null"):
+		p.pos += 4
+		return jpOperand{literal: starlark.None}, nil
+	}
+	if m := jpNumberPattern.FindString(rest); m != "" {
+		p.pos += len(m)
+		if i, err := strconv.ParseInt(m, 10, 64); err == nil {
+			return jpOperand{literal: starlark.MakeInt64(i)}, nil
+		}
+		f, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			return jpOperand{}, p.errorf("invalid number %q", m)
+		}
+		return jpOperand{literal: starlark.Float(f)}, nil
+	}
+	return jpOperand{}, p.errorf("expected @, $, a string, a number, true, false or null")
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccQueryModule_select(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					response = {
+						value = [
+							{ name = "web", properties = { state = "Running", tags = { env = "prod" } } },
+							{ name = "db", properties = { state = "Stopped", tags = { env = "prod", tier = "data" } } },
+							{ name = "dev", properties = { state = "Running", tags = {} } },
+						]
+					}
+				}
+				output "wildcard" {
+					value = provider::starlark::eval("result = query.select(v, '$.value[*].name')", { v = local.response })
+				}
+				output "filter" {
+					value = provider::starlark::eval(
+						"result = query.select(v, \"$.value[?(@.properties.state == 'Running' && @.properties.tags.env)].name\")",
+						{ v = local.response }
+					)
+				}
+				output "recursive" {
+					value = provider::starlark::eval("result = query.select(v, '$..tier')", { v = local.response })
+				}
+				output "slice" {
+					value = provider::starlark::eval("result = query.select(v, 'value[-2:].name')", { v = local.response })
+				}
+				output "missing" {
+					value = provider::starlark::eval("result = query.select(v, '$.value[5].properties.state')", { v = local.response })
+				}
+				output "first" {
+					value = provider::starlark::eval("result = query.first(v, '$.nextLink', 'none')", { v = local.response })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("wildcard", []interface{}{"web", "db", "dev"}),
+					NewTestCheckOutput("filter", []interface{}{"web"}),
+					NewTestCheckOutput("recursive", []interface{}{"data"}),
+					NewTestCheckOutput("slice", []interface{}{"db", "dev"}),
+					NewTestCheckOutput("missing", []interface{}{}),
+					resource.TestCheckOutput("first", "none"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQueryModule_invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = query.select({}, '$.a[')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`invalid query`),
+			},
+		},
+	})
+}
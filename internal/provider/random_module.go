@@ -0,0 +1,289 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// randomModule implements the "random" module. There is no global generator: every function takes
+// an explicit seed, so a script produces the same values on every plan, platform and Go version.
+var randomModule = &starlarkstruct.Module{
+	Name: "random",
+	Members: starlark.StringDict{
+		"new":             starlark.NewBuiltin("random.new", randomNew),
+		"randint":         seededBuiltin("random.randint", randomRandint),
+		"choice":          seededBuiltin("random.choice", randomChoice),
+		"shuffle":         seededBuiltin("random.shuffle", randomShuffle),
+		"sample":          seededBuiltin("random.sample", randomSample),
+		"weighted_choice": seededBuiltin("random.weighted_choice", randomWeightedChoice),
+	},
+}
+
+// randomSource is a deterministic stream of 64-bit values. Block n of the stream is
+// SHA-256(seed || n), with n as a big-endian uint64, read as four big-endian uint64 values.
+type randomSource struct {
+	seed    []byte
+	counter uint64
+	block   []byte
+}
+
+func newRandomSource(seed []byte) *randomSource {
+	return &randomSource{seed: seed}
+}
+
+func (r *randomSource) uint64() uint64 {
+	if len(r.block) == 0 {
+		h := sha256.New()
+		h.Write(r.seed)
+		var n [8]byte
+		binary.BigEndian.PutUint64(n[:], r.counter)
+		h.Write(n[:])
+		r.block = h.Sum(nil)
+		r.counter++
+	}
+	v := binary.BigEndian.Uint64(r.block)
+	r.block = r.block[8:]
+	return v
+}
+
+// uintn returns a uniformly distributed value in [0, n), rejecting values that would bias the result.
+// n must be positive.
+func (r *randomSource) uintn(n uint64) uint64 {
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if v := r.uint64(); v < limit {
+			return v % n
+		}
+	}
+}
+
+// float returns a value in [0, 1) with 53 random bits.
+func (r *randomSource) float() float64 {
+	return float64(r.uint64()>>11) / (1 << 53)
+}
+
+// randomSeed unpacks a seed, which may be a non-empty string, bytes or an int. An int seeds the
+// generator with its decimal representation, so 42 and "42" are the same seed.
+type randomSeed []byte
+
+func (s *randomSeed) Unpack(v starlark.Value) error {
+	switch v := v.(type) {
+	case starlark.String:
+		*s = []byte(v)
+	case starlark.Bytes:
+		*s = []byte(v)
+	case starlark.Int:
+		*s = []byte(v.String())
+	default:
+		return fmt.Errorf("got %s, want string, bytes or int", v.Type())
+	}
+	if len(*s) == 0 {
+		return fmt.Errorf("must not be empty")
+	}
+	return nil
+}
+
+type randomFunc func(r *randomSource, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)
+
+// seededBuiltin returns a builtin that takes the seed as its first positional argument and calls fn
+// with a fresh generator and the remaining arguments.
+func seededBuiltin(name string, fn randomFunc) *starlark.Builtin {
+	return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s: missing argument for seed", b.Name())
+		}
+		var seed randomSeed
+		if err := seed.Unpack(args[0]); err != nil {
+			return nil, fmt.Errorf("%s: seed: %s", b.Name(), err)
+		}
+		return fn(newRandomSource(seed), b, args[1:], kwargs)
+	})
+}
+
+// randomNew returns a generator whose functions share one stream, so successive calls return
+// different values.
+func randomNew(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seed randomSeed
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "seed", &seed); err != nil {
+		return nil, err
+	}
+	r := newRandomSource(seed)
+	bind := func(name string, fn randomFunc) *starlark.Builtin {
+		return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			return fn(r, b, args, kwargs)
+		})
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"randint":         bind("randint", randomRandint),
+		"choice":          bind("choice", randomChoice),
+		"shuffle":         bind("shuffle", randomShuffle),
+		"sample":          bind("sample", randomSample),
+		"weighted_choice": bind("weighted_choice", randomWeightedChoice),
+	}), nil
+}
+
+func randomRandint(r *randomSource, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var lo, hi int64
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &lo, "b", &hi); err != nil {
+		return nil, err
+	}
+	if lo > hi {
+		return nil, fmt.Errorf("%s: empty range: %d > %d", b.Name(), lo, hi)
+	}
+	span := uint64(hi-lo) + 1
+	if span == 0 {
+		return starlark.MakeInt64(int64(r.uint64())), nil
+	}
+	return starlark.MakeInt64(lo + int64(r.uintn(span))), nil
+}
+
+func randomChoice(r *randomSource, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seq starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "seq", &seq); err != nil {
+		return nil, err
+	}
+	values, err := iterableValues(seq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s: cannot choose from an empty sequence", b.Name())
+	}
+	return values[r.uintn(uint64(len(values)))], nil
+}
+
+// randomShuffle returns a shuffled copy of seq; the argument is not modified.
+func randomShuffle(r *randomSource, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seq starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "seq", &seq); err != nil {
+		return nil, err
+	}
+	values, err := iterableValues(seq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	partialShuffle(r, values, len(values))
+	return starlark.NewList(values), nil
+}
+
+// randomSample returns k distinct elements of seq, in selection order.
+func randomSample(r *randomSource, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seq starlark.Value
+	var k int
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "seq", &seq, "k", &k); err != nil {
+		return nil, err
+	}
+	values, err := iterableValues(seq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if k < 0 || k > len(values) {
+		return nil, fmt.Errorf("%s: k must be between 0 and %d, got %d", b.Name(), len(values), k)
+	}
+	partialShuffle(r, values, k)
+	return starlark.NewList(values[:k]), nil
+}
+
+// partialShuffle moves k uniformly chosen elements, in random order, to the front of values.
+func partialShuffle(r *randomSource, values []starlark.Value, k int) {
+	for i := 0; i < k && i < len(values)-1; i++ {
+		j := i + int(r.uintn(uint64(len(values)-i)))
+		values[i], values[j] = values[j], values[i]
+	}
+}
+
+// randomWeightedChoice picks an element of seq with probability proportional to its weight. When
+// every weight is an int the choice is made with integer arithmetic.
+func randomWeightedChoice(r *randomSource, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seq, weights starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "seq", &seq, "weights", &weights); err != nil {
+		return nil, err
+	}
+	values, err := iterableValues(seq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: seq: %s", b.Name(), err)
+	}
+	ws, err := iterableValues(weights)
+	if err != nil {
+		return nil, fmt.Errorf("%s: weights: %s", b.Name(), err)
+	}
+	if len(values) != len(ws) {
+		return nil, fmt.Errorf("%s: got %d weights for %d elements", b.Name(), len(ws), len(values))
+	}
+
+	ints := make([]uint64, len(ws))
+	floats := make([]float64, len(ws))
+	allInts := true
+	var intTotal uint64
+	var floatTotal float64
+	for i, w := range ws {
+		var f float64
+		switch w := w.(type) {
+		case starlark.Int:
+			if w.Sign() < 0 {
+				return nil, fmt.Errorf("%s: weight %d is negative", b.Name(), i)
+			}
+			n, ok := w.Uint64()
+			if !ok || n > math.MaxUint64-intTotal {
+				return nil, fmt.Errorf("%s: weights are too large", b.Name())
+			}
+			ints[i] = n
+			intTotal += n
+			f = float64(n)
+		case starlark.Float:
+			allInts = false
+			f = float64(w)
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("%s: weight %d is not finite", b.Name(), i)
+			}
+			if f < 0 {
+				return nil, fmt.Errorf("%s: weight %d is negative", b.Name(), i)
+			}
+		default:
+			return nil, fmt.Errorf("%s: weight %d: got %s, want int or float", b.Name(), i, w.Type())
+		}
+		floats[i] = f
+		floatTotal += f
+	}
+
+	if allInts {
+		if intTotal == 0 {
+			return nil, fmt.Errorf("%s: total weight must be positive", b.Name())
+		}
+		target := r.uintn(intTotal)
+		for i, w := range ints {
+			if target < w {
+				return values[i], nil
+			}
+			target -= w
+		}
+	} else {
+		if floatTotal <= 0 || math.IsInf(floatTotal, 0) {
+			return nil, fmt.Errorf("%s: total weight must be positive and finite", b.Name())
+		}
+		target := r.float() * floatTotal
+		var cumulative float64
+		last := 0
+		for i, w := range floats {
+			if w == 0 {
+				continue
+			}
+			cumulative += w
+			last = i
+			if target < cumulative {
+				return values[i], nil
+			}
+		}
+		// Rounding can leave target just above the final cumulative sum.
+		return values[last], nil
+	}
+	return nil, fmt.Errorf("%s: no element selected", b.Name())
+}
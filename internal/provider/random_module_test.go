@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRandomModule_seeded(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "randint" {
+					value = provider::starlark::eval("result = [random.randint('vm-%d' % i, 0, 6) for i in range(8)]", {})
+				}
+				output "choice" {
+					value = provider::starlark::eval("result = random.choice('web', zones)", { zones = ["1", "2", "3"] })
+				}
+				output "shuffle" {
+					value = provider::starlark::eval("result = random.shuffle('web', range(10))", {})
+				}
+				output "sample" {
+					value = provider::starlark::eval("result = random.sample('web', zones, 2)", { zones = ["1", "2", "3"] })
+				}
+				output "weighted_choice" {
+					value = provider::starlark::eval("result = random.weighted_choice('web', ['a', 'b', 'c'], [1, 0, 3])", {})
+				}
+				output "generator" {
+					value = provider::starlark::eval(
+						<<-EOT
+						rng = random.new("maint")
+						result = [rng.randint(0, 23) for _ in range(5)]
+						EOT
+						,
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("randint", []interface{}{
+						json.Number("5"), json.Number("1"), json.Number("6"), json.Number("0"),
+						json.Number("0"), json.Number("2"), json.Number("4"), json.Number("4"),
+					}),
+					resource.TestCheckOutput("choice", "1"),
+					NewTestCheckOutput("shuffle", []interface{}{
+						json.Number("3"), json.Number("2"), json.Number("1"), json.Number("7"), json.Number("4"),
+						json.Number("5"), json.Number("9"), json.Number("6"), json.Number("0"), json.Number("8"),
+					}),
+					NewTestCheckOutput("sample", []interface{}{"1", "3"}),
+					resource.TestCheckOutput("weighted_choice", "c"),
+					NewTestCheckOutput("generator", []interface{}{
+						json.Number("18"), json.Number("5"), json.Number("15"), json.Number("19"), json.Number("12"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRandomModule_emptySeed(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = random.choice(name, ['a', 'b'])", { name = "" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`random.choice: seed: must not be empty`),
+			},
+		},
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the interface.
+var _ function.Function = Reduce{}
+
+func NewReduceFunction() function.Function {
+	return Reduce{}
+}
+
+// Reduce implements the "reduce" function.
+type Reduce struct{}
+
+func (f Reduce) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "reduce"
+}
+
+func (f Reduce) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Fold a list into one value with a Starlark lambda",
+		Description: "Calls the Starlark lambda, such as \"lambda acc, x: acc + x\", with the accumulated value and each element of the list in turn, starting from init, and returns the final value.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "list",
+				Description: "The list or tuple to fold.",
+			},
+			function.StringParameter{
+				Name:        "lambda",
+				Description: "A Starlark lambda that takes the accumulated value and one element and returns the new accumulated value.",
+			},
+			function.DynamicParameter{
+				Name:           "init",
+				Description:    "The initial accumulated value.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f Reduce) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var list types.Dynamic
+	var lambda string
+	var init types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &list, &lambda, &init)
+	if resp.Error != nil {
+		return
+	}
+
+	thread := newLambdaThread("reduce")
+	fn, err := compileLambda(thread, lambda)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	elems, err := lambdaList(ctx, list)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	acc, err := attrValueToStarlark(ctx, init)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert init: %s", err))
+		return
+	}
+
+	for i := 0; i < elems.Len(); i++ {
+		if acc, err = callLambda(thread, fn, i, acc, elems.Index(i)); err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+
+	tfVal, err := starlarkToTFValue(ctx, acc)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert result: %s", err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(tfVal))
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccReduceFunction_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "sum" {
+					value = provider::starlark::reduce([1, 2, 3, 4], "lambda acc, x: acc + x", 0)
+				}
+				output "index" {
+					value = provider::starlark::reduce([{ name = "web", port = 80 }, { name = "db", port = 5432 }], "lambda acc, x: acc | {x.name: x.port}", {})
+				}
+				output "accumulator" {
+					value = provider::starlark::reduce([{ port = 80 }, { port = 443 }], "lambda acc, x: {\"count\": acc.count + 1, \"total\": acc.total + x.port}", { count = 0, total = 0 })
+				}
+				output "empty" {
+					value = provider::starlark::reduce([], "lambda acc, x: acc + x", "init")
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("sum", json.Number("10")),
+					NewTestCheckOutput("index", map[string]interface{}{"web": json.Number("80"), "db": json.Number("5432")}),
+					NewTestCheckOutput("accumulator", map[string]interface{}{"count": json.Number("2"), "total": json.Number("523")}),
+					resource.TestCheckOutput("empty", "init"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccReduceFunction_errorIndex(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::reduce(["a", "b", 3], "lambda acc, x: acc + x", "")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`lambda failed at index 2`),
+			},
+		},
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.starlark.net/starlark"
+)
+
+// Ensure the implementation satisfies the interface.
+var _ function.Function = Render{}
+
+func NewRenderFunction() function.Function {
+	return Render{}
+}
+
+// Render implements the "render" function.
+type Render struct{}
+
+func (f Render) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render"
+}
+
+func (f Render) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Render a template with embedded Starlark",
+		Description: "Renders a template in which {{ expr }} tags and {% if %}, {% for %} and {% set %} blocks are evaluated as Starlark with the given inputs.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "template",
+				Description: "The template text.",
+			},
+			function.DynamicParameter{
+				Name:        "inputs",
+				Description: "A map of variables that the template can use.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f Render) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	var inputs types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &template, &inputs)
+	if resp.Error != nil {
+		return
+	}
+
+	thread := &starlark.Thread{
+		Name:  "terraform-provider-starlark-render",
+		Print: func(_ *starlark.Thread, msg string) { fmt.Println(msg) },
+	}
+
+	globals, err := inputGlobals(ctx, inputs)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	text, err := renderTemplate(thread, template, globals)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("template rendering failed: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, text)
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRenderFunction_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "expr" {
+					value = provider::starlark::render("Hello, {{ name.title() }}! You have {{ len(items) * 2 }} items.", {
+						name  = "alice"
+						items = ["a", "b"]
+					})
+				}
+				output "filters" {
+					value = provider::starlark::render("<b>{{ name | html }}</b> echo {{ name | shell }} {{ tags | json }}", {
+						name = "O'Brien <admin>"
+						tags = { env = "prod" }
+					})
+				}
+				output "numbers" {
+					value = provider::starlark::render("port={{ port }} next={{ port + 1 }} ratio={{ ratio }} json={{ {'port': port, 'ratio': ratio, 'ports': [port]} | json }}", {
+						port  = 8080
+						ratio = 0.5
+					})
+				}
+				output "nested_braces" {
+					value = provider::starlark::render("{{ {'a': {'b': 1}}['a'] | json }} {{ '}}' }}", {})
+				}
+				output "modules" {
+					value = provider::starlark::render("{{ text.snake_case(name) }}-{{ hash.sha256(name)[:8] }}", { name = "MyApp" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("expr", "Hello, Alice! You have 4 items."),
+					resource.TestCheckOutput("filters", `<b>O&#39;Brien &lt;admin&gt;</b> echo 'O'\''Brien <admin>' {"env":"prod"}`),
+					resource.TestCheckOutput("numbers", `port=8080 next=8081 ratio=0.5 json={"port":8080,"ports":[8080],"ratio":0.5}`),
+					resource.TestCheckOutput("nested_braces", `{"b":1} }}`),
+					resource.TestCheckOutput("modules", "my_app-4de339ce"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRenderFunction_blocks(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "loop" {
+					value = provider::starlark::render(
+						<<-EOT
+						{% for s in servers -%}
+						{{ loop.index }}. {{ s | upper }}{% if not loop.last %}, {% endif %}
+						{%- endfor %}
+						EOT
+						,
+						{ servers = ["web-1", "web-2", "db-1"] }
+					)
+				}
+				output "branches" {
+					value = provider::starlark::render("{% set n = len(servers) %}{% if n > 5 %}many{% elif n > 2 %}some ({{ n }}){% else %}few{% endif %}", {
+						servers = ["web-1", "web-2", "db-1"]
+					})
+				}
+				output "items" {
+					value = provider::starlark::render("{# sorted by key #}{% for k, v in tags.items() %}{{ k }}={{ v }};{% endfor %}", {
+						tags = { team = "core", env = "prod" }
+					})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("loop", "1. WEB-1, 2. WEB-2, 3. DB-1\n"),
+					resource.TestCheckOutput("branches", "some (3)"),
+					resource.TestCheckOutput("items", "env=prod;team=core;"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRenderFunction_errorLine(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::render("first line\nsecond {{ missing }}", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`line 2: undefined: missing`),
+			},
+		},
+	})
+}
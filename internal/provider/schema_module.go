@@ -0,0 +1,184 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// schemaModule implements the "schema" module for JSON Schema validation.
+var schemaModule = &starlarkstruct.Module{
+	Name: "schema",
+	Members: starlark.StringDict{
+		"validate": starlark.NewBuiltin("schema.validate", schemaValidate),
+	},
+}
+
+// schemaResourceURL is the base URL of the schema being validated against, unless it sets its own $id.
+const schemaResourceURL = "urn:starlark:schema"
+
+// schemaPrinter formats validation messages.
+var schemaPrinter = message.NewPrinter(language.English)
+
+// schemaValidate validates value against a JSON Schema and returns a list of {path, message} dicts,
+// sorted by path. An empty list means the value is valid. Schemas without "$schema" are treated as
+// draft 2020-12. References to schemas outside the document are not loaded.
+func schemaValidate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value, schema starlark.Value
+	assertFormat := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "schema", &schema, "assert_format?", &assertFormat); err != nil {
+		return nil, err
+	}
+
+	doc, err := starlarkToJSONValue(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%s: schema: %s", b.Name(), err)
+	}
+	instance, err := starlarkToJSONValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: value: %s", b.Name(), err)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft2020)
+	c.UseLoader(noRemoteSchemas{})
+	if assertFormat {
+		c.AssertFormat()
+	}
+	if err := c.AddResource(schemaResourceURL, doc); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	sch, err := c.Compile(schemaResourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid schema: %s", b.Name(), err)
+	}
+
+	err = sch.Validate(instance)
+	var verr *jsonschema.ValidationError
+	if err != nil && !errors.As(err, &verr) {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	var violations []schemaViolation
+	if verr != nil {
+		violations = collectViolations(verr, nil)
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].path != violations[j].path {
+			return violations[i].path < violations[j].path
+		}
+		return violations[i].message < violations[j].message
+	})
+
+	result := make([]starlark.Value, 0, len(violations))
+	for i, v := range violations {
+		if i > 0 && v == violations[i-1] {
+			continue
+		}
+		d := starlark.NewDict(2)
+		_ = d.SetKey(starlark.String("path"), starlark.String(v.path))
+		_ = d.SetKey(starlark.String("message"), starlark.String(v.message))
+		result = append(result, d)
+	}
+	return starlark.NewList(result), nil
+}
+
+type schemaViolation struct {
+	path    string
+	message string
+}
+
+// collectViolations returns the leaves of a validation error tree. Inner nodes, such as a failed
+// allOf or $ref, only summarise their causes.
+func collectViolations(e *jsonschema.ValidationError, out []schemaViolation) []schemaViolation {
+	if len(e.Causes) == 0 {
+		return append(out, schemaViolation{
+			path:    formatPointer(e.InstanceLocation),
+			message: e.ErrorKind.LocalizedString(schemaPrinter),
+		})
+	}
+	for _, cause := range e.Causes {
+		out = collectViolations(cause, out)
+	}
+	return out
+}
+
+type noRemoteSchemas struct{}
+
+func (noRemoteSchemas) Load(url string) (any, error) {
+	return nil, fmt.Errorf("loading %s: only references within the schema and to the standard meta-schemas are supported", url)
+}
+
+// starlarkToJSONValue converts a Starlark value to the representation produced by encoding/json,
+// with numbers as json.Number so integers keep their precision.
+func starlarkToJSONValue(v starlark.Value) (any, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Int:
+		return json.Number(v.String()), nil
+	case starlark.Float:
+		f := float64(v)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("cannot convert non-finite float %v", v)
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	case starlark.IterableMapping:
+		obj := make(map[string]any)
+		for _, item := range v.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("dict keys must be strings, got %s", item[0].Type())
+			}
+			conv, err := starlarkToJSONValue(item[1])
+			if err != nil {
+				return nil, err
+			}
+			obj[string(k)] = conv
+		}
+		return obj, nil
+	case starlark.Indexable:
+		arr := make([]any, v.Len())
+		for i := range arr {
+			conv, err := starlarkToJSONValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = conv
+		}
+		return arr, nil
+	case *starlarkstruct.Struct:
+		obj := make(map[string]any)
+		for _, name := range v.AttrNames() {
+			attr, err := v.Attr(name)
+			if err != nil {
+				return nil, err
+			}
+			conv, err := starlarkToJSONValue(attr)
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = conv
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("cannot convert %s to JSON", strings.TrimSpace(v.Type()))
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSchemaModule_validate(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					schema = {
+						type     = "object"
+						required = ["name", "size"]
+						properties = {
+							name = { type = "string", minLength = 3 }
+							size = { "$ref" = "#/$defs/size" }
+							tags = { type = "array", items = { type = "string" } }
+							mode = { enum = ["a", "b"] }
+						}
+						additionalProperties = false
+						"$defs" = {
+							size = { type = "integer", minimum = 1 }
+						}
+					}
+				}
+				output "valid" {
+					value = provider::starlark::eval(
+						"result = schema.validate(config, schema)",
+						{
+							config = { name = "web", size = 2 }
+							schema = local.schema
+						}
+					)
+				}
+				output "invalid" {
+					value = provider::starlark::eval(
+						"result = schema.validate(config, schema)",
+						{
+							config = { name = "w", size = 0, tags = ["a", 1], mode = "c", extra = true }
+							schema = local.schema
+						}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("valid", []interface{}{}),
+					NewTestCheckOutput("invalid", []interface{}{
+						map[string]interface{}{"path": "", "message": "additional properties 'extra' not allowed"},
+						map[string]interface{}{"path": "/mode", "message": "value must be one of 'a', 'b'"},
+						map[string]interface{}{"path": "/name", "message": "minLength: got 1, want 3"},
+						map[string]interface{}{"path": "/size", "message": "minimum: got 0, want 1"},
+						map[string]interface{}{"path": "/tags/1", "message": "got number, want string"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSchemaModule_format(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "annotation" {
+					value = provider::starlark::eval("result = schema.validate('nope', {'format': 'email'})", {})
+				}
+				output "assertion" {
+					value = provider::starlark::eval("result = schema.validate('nope', {'format': 'email'}, assert_format=True)", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("annotation", []interface{}{}),
+					NewTestCheckOutput("assertion", []interface{}{
+						map[string]interface{}{"path": "", "message": "'nope' is not valid email: missing @"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSchemaModule_invalidSchema(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = schema.validate(1, {'type': 'nope'})", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`schema.validate: invalid schema`),
+			},
+		},
+	})
+}
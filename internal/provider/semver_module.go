@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// semverModule implements the "semver" module.
+//
+// Versions and constraints are handled by hashicorp/go-version, the same library
+// Terraform uses for required_version, so constraint strings such as
+// ">= 1.2, < 2.0" and "~> 1.29" behave exactly as they do there.
+var semverModule = &starlarkstruct.Module{
+	Name: "semver",
+	Members: starlark.StringDict{
+		"parse":   starlark.NewBuiltin("semver.parse", semverParse),
+		"valid":   starlark.NewBuiltin("semver.valid", semverValid),
+		"compare": starlark.NewBuiltin("semver.compare", semverCompare),
+		"sort":    starlark.NewBuiltin("semver.sort", semverSort),
+		"bump":    starlark.NewBuiltin("semver.bump", semverBump),
+		"matches": starlark.NewBuiltin("semver.matches", semverMatches),
+	},
+}
+
+func semverParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var v string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "version", &v); err != nil {
+		return nil, err
+	}
+	ver, err := version.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	segments := ver.Segments64()
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"major":      starlark.MakeInt64(segments[0]),
+		"minor":      starlark.MakeInt64(segments[1]),
+		"patch":      starlark.MakeInt64(segments[2]),
+		"prerelease": starlark.String(ver.Prerelease()),
+		"build":      starlark.String(ver.Metadata()),
+	}), nil
+}
+
+func semverValid(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var v string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "version", &v); err != nil {
+		return nil, err
+	}
+	_, err := version.NewVersion(v)
+	return starlark.Bool(err == nil), nil
+}
+
+func semverCompare(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "a", &x, "b", &y); err != nil {
+		return nil, err
+	}
+	a, err := version.NewVersion(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	c, err := version.NewVersion(y)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.MakeInt(a.Compare(c)), nil
+}
+
+func semverSort(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var versions *starlark.List
+	reverse := false
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "versions", &versions, "reverse?", &reverse); err != nil {
+		return nil, err
+	}
+
+	parsed := make(version.Collection, 0, versions.Len())
+	for i := 0; i < versions.Len(); i++ {
+		s, ok := starlark.AsString(versions.Index(i))
+		if !ok {
+			return nil, fmt.Errorf("%s: at index %d: got %s, want string", b.Name(), i, versions.Index(i).Type())
+		}
+		ver, err := version.NewVersion(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: at index %d: %s", b.Name(), i, err)
+		}
+		parsed = append(parsed, ver)
+	}
+	if reverse {
+		sort.Stable(sort.Reverse(parsed))
+	} else {
+		sort.Stable(parsed)
+	}
+
+	elems := make([]starlark.Value, 0, len(parsed))
+	for _, ver := range parsed {
+		elems = append(elems, starlark.String(ver.Original()))
+	}
+	return starlark.NewList(elems), nil
+}
+
+// semverBump increments the given part of a version. Prerelease and build metadata are dropped.
+func semverBump(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var v string
+	part := "patch"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "version", &v, "part?", &part); err != nil {
+		return nil, err
+	}
+	ver, err := version.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	// As in npm, a prerelease of the version that the bump leads to is bumped to its release,
+	// as in 1.2.3-rc.1 -> 1.2.3 for patch, 1.2.0-rc.1 -> 1.2.0 for minor and 2.0.0-rc.1 -> 2.0.0
+	// for major.
+	s := ver.Segments64()
+	major, minor, patch := s[0], s[1], s[2]
+	pre := ver.Prerelease() != ""
+	switch part {
+	case "major":
+		if !pre || minor != 0 || patch != 0 {
+			major++
+		}
+		minor, patch = 0, 0
+	case "minor":
+		if !pre || patch != 0 {
+			minor++
+		}
+		patch = 0
+	case "patch":
+		if !pre {
+			patch++
+		}
+	default:
+		return nil, fmt.Errorf("%s: part must be \"major\", \"minor\" or \"patch\", got %q", b.Name(), part)
+	}
+	return starlark.String(fmt.Sprintf("%d.%d.%d", major, minor, patch)), nil
+}
+
+func semverMatches(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var v, constraint string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "version", &v, "constraint", &constraint); err != nil {
+		return nil, err
+	}
+	ver, err := version.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.Bool(constraints.Check(ver)), nil
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSemverModule_parse(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "parsed" {
+					value = provider::starlark::eval("result = semver.parse(v)", { v = "v1.29.3-rc.1+build.5" })
+				}
+				output "bumped" {
+					value = provider::starlark::eval("result = [semver.bump(v, 'major'), semver.bump(v, 'minor'), semver.bump(v)]", { v = "1.2.3" })
+				}
+				output "bumped_prerelease" {
+					value = provider::starlark::eval("result = [semver.bump(v, p) for v, p in cases]", {
+						cases = [
+							["2.0.0-rc.1", "major"], ["2.1.0-rc.1", "major"],
+							["1.2.0-rc.1", "minor"], ["1.2.3-rc.1", "minor"],
+							["1.2.3-rc.1", "patch"],
+						]
+					})
+				}
+				output "sorted" {
+					value = provider::starlark::eval("result = semver.sort(v)", { v = ["1.10.0", "1.2.3", "1.2.10", "1.2.3-alpha"] })
+				}
+				output "compared" {
+					value = provider::starlark::eval("result = semver.compare('1.2.3', v)", { v = "1.10.0" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("parsed", map[string]interface{}{
+						"major":      json.Number("1"),
+						"minor":      json.Number("29"),
+						"patch":      json.Number("3"),
+						"prerelease": "rc.1",
+						"build":      "build.5",
+					}),
+					NewTestCheckOutput("bumped", []interface{}{"2.0.0", "1.3.0", "1.2.4"}),
+					NewTestCheckOutput("bumped_prerelease", []interface{}{"2.0.0", "3.0.0", "1.2.0", "1.3.0", "1.2.3"}),
+					NewTestCheckOutput("sorted", []interface{}{"1.2.3-alpha", "1.2.3", "1.2.10", "1.10.0"}),
+					resource.TestCheckOutput("compared", "-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSemverModule_matches(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "range" {
+					value = provider::starlark::eval("result = semver.matches(v, '>= 1.2, < 2.0')", { v = "1.29.0" })
+				}
+				output "upper_bound" {
+					value = provider::starlark::eval("result = semver.matches(v, '>= 1.2, < 2.0')", { v = "2.0.0" })
+				}
+				output "pessimistic" {
+					value = provider::starlark::eval("result = semver.matches(v, '~> 1.2.0')", { v = "1.3.0" })
+				}
+				output "prerelease" {
+					value = provider::starlark::eval("result = semver.matches(v, '>= 1.29')", { v = "1.30.0-rc.1" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("range", "true"),
+					resource.TestCheckOutput("upper_bound", "false"),
+					resource.TestCheckOutput("pessimistic", "false"),
+					resource.TestCheckOutput("prerelease", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// Ensure the implementation satisfies the interface.
+var _ function.Function = SortBy{}
+
+func NewSortByFunction() function.Function {
+	return SortBy{}
+}
+
+// SortBy implements the "sort_by" function.
+type SortBy struct{}
+
+func (f SortBy) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sort_by"
+}
+
+func (f SortBy) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Sort a list by a key computed with a Starlark lambda",
+		Description: "Returns the elements of the list sorted by the key that the Starlark lambda, such as \"lambda x: x.name\", returns for each of them. Elements with equal keys keep their order.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "list",
+				Description: "The list or tuple to sort.",
+			},
+			function.StringParameter{
+				Name:        "lambda",
+				Description: "A Starlark lambda that takes one element and returns its sort key.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f SortBy) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var list types.Dynamic
+	var lambda string
+
+	resp.Error = req.Arguments.Get(ctx, &list, &lambda)
+	if resp.Error != nil {
+		return
+	}
+
+	thread := newLambdaThread("sort_by")
+	fn, err := compileLambda(thread, lambda)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	elems, err := lambdaList(ctx, list)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	n := elems.Len()
+	keys := make([]starlark.Value, n)
+	order := make([]int, n)
+	for i := range keys {
+		if keys[i], err = callLambda(thread, fn, i, elems.Index(i)); err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		order[i] = i
+	}
+
+	var cmpErr error
+	sort.SliceStable(order, func(a, b int) bool {
+		less, err := starlark.Compare(syntax.LT, keys[order[a]], keys[order[b]])
+		if err != nil && cmpErr == nil {
+			cmpErr = fmt.Errorf("cannot compare the keys of the elements at index %d and %d: %s", order[a], order[b], err)
+		}
+		return less
+	})
+	if cmpErr != nil {
+		resp.Error = function.NewFuncError(cmpErr.Error())
+		return
+	}
+
+	out := make([]starlark.Value, n)
+	for i, j := range order {
+		out[i] = elems.Index(j)
+	}
+
+	tfVal, err := starlarkToTFValue(ctx, starlark.NewList(out))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to convert result: %s", err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(tfVal))
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSortByFunction_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "by_name" {
+					value = provider::starlark::sort_by([{ name = "web" }, { name = "api" }, { name = "db" }], "lambda x: x.name")
+				}
+				output "stable" {
+					value = provider::starlark::sort_by(["ccc", "a", "bb", "b", "aa"], "lambda x: len(x)")
+				}
+				output "descending" {
+					value = provider::starlark::sort_by([3, 1, 2], "lambda x: -x")
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("by_name", []interface{}{map[string]interface{}{"name": "api"}, map[string]interface{}{"name": "db"}, map[string]interface{}{"name": "web"}}),
+					NewTestCheckOutput("stable", []interface{}{"a", "b", "bb", "aa", "ccc"}),
+					NewTestCheckOutput("descending", []interface{}{json.Number("3"), json.Number("2"), json.Number("1")}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSortByFunction_mixedKeys(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::sort_by(["a", 1], "lambda x: x")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`cannot compare`),
+			},
+		},
+	})
+}
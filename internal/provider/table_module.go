@@ -0,0 +1,418 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// tableModule implements the "table" module, which shapes lists of dicts such as those returned by csv.parse.
+//
+// Wherever a key is expected, it may be a column name or a function that is called with the row.
+var tableModule = &starlarkstruct.Module{
+	Name: "table",
+	Members: starlark.StringDict{
+		"group_by": starlark.NewBuiltin("table.group_by", tableGroupBy),
+		"index_by": starlark.NewBuiltin("table.index_by", tableIndexBy),
+		"join":     starlark.NewBuiltin("table.join", tableJoin),
+		"pivot":    starlark.NewBuiltin("table.pivot", tablePivot),
+		"distinct": starlark.NewBuiltin("table.distinct", tableDistinct),
+	},
+}
+
+// tableRows returns the rows of a table, checking that each one is a dict.
+func tableRows(rows *starlark.List) ([]*starlark.Dict, error) {
+	out := make([]*starlark.Dict, 0, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		row, ok := rows.Index(i).(*starlark.Dict)
+		if !ok {
+			return nil, fmt.Errorf("row %d: got %s, want dict", i, rows.Index(i).Type())
+		}
+		out = append(out, row)
+	}
+	return out, nil
+}
+
+// rowKey extracts the key of a row, either by column name or by calling a function.
+func rowKey(thread *starlark.Thread, row *starlark.Dict, key starlark.Value) (starlark.Value, error) {
+	if column, ok := key.(starlark.String); ok {
+		v, found, err := row.Get(column)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("missing column %s", column)
+		}
+		return v, nil
+	}
+	if fn, ok := key.(starlark.Callable); ok {
+		return starlark.Call(thread, fn, starlark.Tuple{row}, nil)
+	}
+	return nil, fmt.Errorf("key must be a column name or a function, got %s", key.Type())
+}
+
+func tableGroupBy(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rows *starlark.List
+	var key starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rows", &rows, "key", &key); err != nil {
+		return nil, err
+	}
+	table, err := tableRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	groups := newTableGroups()
+	for i, row := range table {
+		k, err := rowKey(thread, row, key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+		if err := groups.add(k, row); err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+	}
+	return groups.dict(), nil
+}
+
+func tableIndexBy(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rows *starlark.List
+	var key starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rows", &rows, "key", &key); err != nil {
+		return nil, err
+	}
+	table, err := tableRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	index := starlark.NewDict(len(table))
+	for i, row := range table {
+		k, err := rowKey(thread, row, key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+		if _, found, _ := index.Get(k); found {
+			return nil, fmt.Errorf("%s: row %d: duplicate key %s", b.Name(), i, k)
+		}
+		if err := index.SetKey(k, row); err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+	}
+	return index, nil
+}
+
+// tableJoin joins two tables on a key. Columns of the right row that clash with the left row
+// are renamed with the given suffix.
+func tableJoin(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var left, right *starlark.List
+	var on starlark.Value
+	var rightOn starlark.Value
+	how := "inner"
+	suffix := "_right"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "left", &left, "right", &right, "on", &on, "right_on?", &rightOn, "how?", &how, "suffix?", &suffix); err != nil {
+		return nil, err
+	}
+	if how != "inner" && how != "left" {
+		return nil, fmt.Errorf("%s: how must be \"inner\" or \"left\", got %q", b.Name(), how)
+	}
+	if rightOn == nil {
+		rightOn = on
+	}
+
+	leftRows, err := tableRows(left)
+	if err != nil {
+		return nil, fmt.Errorf("%s: left: %s", b.Name(), err)
+	}
+	rightRows, err := tableRows(right)
+	if err != nil {
+		return nil, fmt.Errorf("%s: right: %s", b.Name(), err)
+	}
+
+	// Group the right rows by key, preserving their order.
+	matches := newTableGroups()
+	for i, row := range rightRows {
+		k, err := rowKey(thread, row, rightOn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: right row %d: %s", b.Name(), i, err)
+		}
+		if err := matches.add(k, row); err != nil {
+			return nil, fmt.Errorf("%s: right row %d: %s", b.Name(), i, err)
+		}
+	}
+
+	var joined []starlark.Value
+	for i, row := range leftRows {
+		k, err := rowKey(thread, row, on)
+		if err != nil {
+			return nil, fmt.Errorf("%s: left row %d: %s", b.Name(), i, err)
+		}
+		group, err := matches.get(k)
+		if err != nil {
+			return nil, fmt.Errorf("%s: left row %d: %s", b.Name(), i, err)
+		}
+		if len(group) == 0 {
+			if how == "left" {
+				joined = append(joined, copyDict(row))
+			}
+			continue
+		}
+		for _, match := range group {
+			merged := copyDict(row)
+			for _, item := range match.Items() {
+				name := item[0]
+				if _, clash, _ := merged.Get(name); clash {
+					if name == on {
+						continue
+					}
+					s, ok := name.(starlark.String)
+					if !ok {
+						return nil, fmt.Errorf("%s: column names must be strings, got %s", b.Name(), name.Type())
+					}
+					name = s + starlark.String(suffix)
+				}
+				if err := merged.SetKey(name, item[1]); err != nil {
+					return nil, err
+				}
+			}
+			joined = append(joined, merged)
+		}
+	}
+	return starlark.NewList(joined), nil
+}
+
+// tablePivot reshapes rows into a dict of index -> {column -> value}. When several rows share the
+// same index and column, agg decides how their values are combined; without it they are an error.
+func tablePivot(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rows *starlark.List
+	var index, columns, values starlark.Value
+	agg := ""
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rows", &rows, "index", &index, "columns", &columns, "values", &values, "agg?", &agg); err != nil {
+		return nil, err
+	}
+	switch agg {
+	case "", "first", "last", "sum", "count", "min", "max", "list":
+	default:
+		return nil, fmt.Errorf("%s: unsupported agg %q", b.Name(), agg)
+	}
+	table, err := tableRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	pivot := newTableGroups()
+	cells := map[int]*starlark.Dict{}
+	for i, row := range table {
+		ik, err := rowKey(thread, row, index)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+		ck, err := rowKey(thread, row, columns)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+		v, err := rowKey(thread, row, values)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+
+		pos, err := pivot.position(ik)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+		dict, ok := cells[pos]
+		if !ok {
+			dict = starlark.NewDict(0)
+			cells[pos] = dict
+		}
+		prev, found, err := dict.Get(ck)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+		}
+
+		cell, err := pivotCell(agg, prev, found, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d, %s / %s: %s", b.Name(), i, ik, ck, err)
+		}
+		if err := dict.SetKey(ck, cell); err != nil {
+			return nil, err
+		}
+	}
+
+	out := starlark.NewDict(len(pivot.keys))
+	for pos, k := range pivot.keys {
+		if err := out.SetKey(k, cells[pos]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// pivotCell combines the value of a pivot cell with the previous one, if found, according to agg.
+func pivotCell(agg string, prev starlark.Value, found bool, v starlark.Value) (starlark.Value, error) {
+	if !found {
+		switch agg {
+		case "count":
+			return starlark.MakeInt(1), nil
+		case "list":
+			return starlark.NewList([]starlark.Value{v}), nil
+		default:
+			return v, nil
+		}
+	}
+
+	switch agg {
+	case "first":
+		return prev, nil
+	case "last":
+		return v, nil
+	case "count":
+		return starlark.Binary(syntax.PLUS, prev, starlark.MakeInt(1))
+	case "sum":
+		return starlark.Binary(syntax.PLUS, prev, v)
+	case "list":
+		list, ok := prev.(*starlark.List)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s in list aggregate", prev.Type())
+		}
+		return list, list.Append(v)
+	case "min", "max":
+		less, err := starlark.Compare(syntax.LT, v, prev)
+		if err != nil {
+			return nil, err
+		}
+		if less == (agg == "min") {
+			return v, nil
+		}
+		return prev, nil
+	default:
+		return nil, fmt.Errorf("duplicate entry, set agg to combine values")
+	}
+}
+
+// tableDistinct removes duplicate rows, keeping the first occurrence. When keys are given,
+// rows are compared on those columns only.
+func tableDistinct(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rows *starlark.List
+	var keys *starlark.List
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rows", &rows, "keys?", &keys); err != nil {
+		return nil, err
+	}
+
+	var out []starlark.Value
+	var seen []starlark.Value
+	for i := 0; i < rows.Len(); i++ {
+		row := rows.Index(i)
+		identity := row
+		if keys != nil {
+			dict, ok := row.(*starlark.Dict)
+			if !ok {
+				return nil, fmt.Errorf("%s: row %d: got %s, want dict", b.Name(), i, row.Type())
+			}
+			parts := make(starlark.Tuple, 0, keys.Len())
+			for j := 0; j < keys.Len(); j++ {
+				v, err := rowKey(thread, dict, keys.Index(j))
+				if err != nil {
+					return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+				}
+				parts = append(parts, v)
+			}
+			identity = parts
+		}
+
+		// Rows are usually unhashable dicts, so compare them by value.
+		duplicate := false
+		for _, s := range seen {
+			eq, err := starlark.Equal(s, identity)
+			if err != nil {
+				return nil, fmt.Errorf("%s: row %d: %s", b.Name(), i, err)
+			}
+			if eq {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			seen = append(seen, identity)
+			out = append(out, row)
+		}
+	}
+	return starlark.NewList(out), nil
+}
+
+// tableGroups collects rows under Starlark keys, preserving the order in which keys are first seen.
+type tableGroups struct {
+	index *starlark.Dict // key -> position in keys and rows
+	keys  []starlark.Value
+	rows  [][]*starlark.Dict
+}
+
+func newTableGroups() *tableGroups {
+	return &tableGroups{index: starlark.NewDict(0)}
+}
+
+// position returns the position of key, adding it if it has not been seen yet.
+func (g *tableGroups) position(key starlark.Value) (int, error) {
+	v, found, err := g.index.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if found {
+		return starlark.AsInt32(v)
+	}
+	pos := len(g.keys)
+	if err := g.index.SetKey(key, starlark.MakeInt(pos)); err != nil {
+		return 0, err
+	}
+	g.keys = append(g.keys, key)
+	g.rows = append(g.rows, nil)
+	return pos, nil
+}
+
+func (g *tableGroups) add(key starlark.Value, row *starlark.Dict) error {
+	pos, err := g.position(key)
+	if err != nil {
+		return err
+	}
+	g.rows[pos] = append(g.rows[pos], row)
+	return nil
+}
+
+// get returns the rows grouped under key, or nil if there are none.
+func (g *tableGroups) get(key starlark.Value) ([]*starlark.Dict, error) {
+	v, found, err := g.index.Get(key)
+	if err != nil || !found {
+		return nil, err
+	}
+	pos, err := starlark.AsInt32(v)
+	if err != nil {
+		return nil, err
+	}
+	return g.rows[pos], nil
+}
+
+// dict returns the groups as a dict of key -> list of rows.
+func (g *tableGroups) dict() *starlark.Dict {
+	out := starlark.NewDict(len(g.keys))
+	for pos, k := range g.keys {
+		elems := make([]starlark.Value, 0, len(g.rows[pos]))
+		for _, row := range g.rows[pos] {
+			elems = append(elems, row)
+		}
+		_ = out.SetKey(k, starlark.NewList(elems))
+	}
+	return out
+}
+
+func copyDict(d *starlark.Dict) *starlark.Dict {
+	out := starlark.NewDict(d.Len())
+	for _, item := range d.Items() {
+		_ = out.SetKey(item[0], item[1])
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTableModule_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					subnets = [
+						{ name = "web", zone = "1" },
+						{ name = "app", zone = "1" },
+						{ name = "db", zone = "2" },
+					]
+					zones = [
+						{ zone = "1", region = "westeurope" },
+						{ zone = "2", region = "northeurope" },
+					]
+				}
+				output "group_by" {
+					value = provider::starlark::eval(
+						"result = {k: [r['name'] for r in v] for k, v in table.group_by(subnets, 'zone').items()}",
+						{ subnets = local.subnets }
+					)
+				}
+				output "index_by" {
+					value = provider::starlark::eval(
+						"result = table.index_by(subnets, 'name')['db']",
+						{ subnets = local.subnets }
+					)
+				}
+				output "join" {
+					value = provider::starlark::eval(
+						"result = [r['name'] + '@' + r['region'] for r in table.join(subnets, zones, 'zone')]",
+						{ subnets = local.subnets, zones = local.zones }
+					)
+				}
+				output "distinct" {
+					value = provider::starlark::eval(
+						"result = [r['zone'] for r in table.distinct(subnets, keys = ['zone'])]",
+						{ subnets = local.subnets }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("group_by", map[string]interface{}{
+						"1": []interface{}{"web", "app"},
+						"2": []interface{}{"db"},
+					}),
+					NewTestCheckOutput("index_by", map[string]interface{}{"name": "db", "zone": "2"}),
+					NewTestCheckOutput("join", []interface{}{"web@westeurope", "app@westeurope", "db@northeurope"}),
+					NewTestCheckOutput("distinct", []interface{}{"1", "2"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTableModule_pivot(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "pivot" {
+					value = provider::starlark::eval(
+						"result = table.pivot(rows, 'env', 'region', 'vms', agg = 'sum')",
+						{
+							rows = [
+								{ env = "dev", region = "eu", vms = 1 },
+								{ env = "dev", region = "eu", vms = 2 },
+								{ env = "prod", region = "us", vms = 5 },
+							]
+						}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("pivot", map[string]interface{}{
+						"dev":  map[string]interface{}{"eu": json.Number("3")},
+						"prod": map[string]interface{}{"us": json.Number("5")},
+					}),
+				),
+			},
+		},
+	})
+}
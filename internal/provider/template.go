@@ -0,0 +1,589 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.starlark.net/lib/json"
+	"go.starlark.net/resolve"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// A template is text with embedded Starlark:
+//
+//	{{ expr }} or {{ expr | filter | ... }}    the value of expr
+//	{% if expr %} ... {% elif expr %} ... {% else %} ... {% endif %}
+//	{% for x in expr %} ... {% endfor %}     also {% for k, v in expr %}
+//	{% set name = expr %}
+//	{# comment #}
+//
+// A "-" just inside a tag, as in {{- or -%}, removes the whitespace before or after the tag.
+
+var (
+	templateForPattern = regexp.MustCompile(`^for\s+(.+?)\s+in\s+(.+)$`)
+	templateSetPattern = regexp.MustCompile(`^set\s+([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.+)$`)
+	templateIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// templateFilters transform the value of a {{ }} tag. Every filter but json works on the text of
+// the value.
+var templateFilters = map[string]func(string) string{
+	"html":  html.EscapeString,
+	"shell": shellQuote,
+	"url":   url.QueryEscape,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"json":  nil,
+}
+
+// shellQuote quotes s as a single word for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+type templateTokenKind int
+
+const (
+	templateText templateTokenKind = iota
+	templateExpr
+	templateStmt
+)
+
+type templateToken struct {
+	kind templateTokenKind
+	text string
+	line int
+}
+
+// templateNode is one of *templateTextNode, *templateExprNode, *templateIfNode, *templateForNode
+// or *templateSetNode.
+type templateNode interface{}
+
+type templateTextNode struct {
+	text string
+}
+
+type templateExprNode struct {
+	line    int
+	expr    syntax.Expr
+	filters []string
+}
+
+type templateIfNode struct {
+	branches []templateBranch
+}
+
+type templateBranch struct {
+	line int
+	cond syntax.Expr // nil for else
+	body []templateNode
+}
+
+type templateForNode struct {
+	line    int
+	targets []string
+	iter    syntax.Expr
+	body    []templateNode
+}
+
+type templateSetNode struct {
+	line int
+	name string
+	expr syntax.Expr
+}
+
+type templateError struct {
+	line int
+	msg  string
+}
+
+func (e *templateError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.line, e.msg)
+}
+
+func templateErrorf(line int, format string, args ...interface{}) error {
+	return &templateError{line: line, msg: fmt.Sprintf(format, args...)}
+}
+
+// tokenizeTemplate splits src into text, {{ }} and {% %} tokens. Comments are dropped.
+func tokenizeTemplate(src string) ([]templateToken, error) {
+	var tokens []templateToken
+	line := 1
+	pos := 0
+	for pos < len(src) {
+		start := -1
+		for _, open := range []string{"{{", "{%", "{#"} {
+			if i := strings.Index(src[pos:], open); i >= 0 && (start < 0 || pos+i < start) {
+				start = pos + i
+			}
+		}
+		if start < 0 {
+			tokens = append(tokens, templateToken{kind: templateText, text: src[pos:], line: line})
+			break
+		}
+
+		text := src[pos:start]
+		textLine := line
+		line += strings.Count(text, "\n")
+		tagLine := line
+		inner := start + 2
+		if strings.HasPrefix(src[inner:], "-") {
+			text = strings.TrimRight(text, " \t\r\n")
+			inner++
+		}
+		if text != "" {
+			tokens = append(tokens, templateToken{kind: templateText, text: text, line: textLine})
+		}
+
+		closing := map[byte]string{'{': "}}", '%': "%}", '#': "#}"}[src[start+1]]
+		end := strings.Index(src[inner:], closing)
+		if src[start+1] != '#' {
+			end = findTagEnd(src[inner:], closing)
+		}
+		if end < 0 {
+			return nil, templateErrorf(tagLine, "%s is not closed", src[start:start+2])
+		}
+		body := src[inner : inner+end]
+		pos = inner + end + 2
+		line += strings.Count(src[start:pos], "\n")
+		trimAfter := strings.HasSuffix(body, "-")
+		body = strings.TrimSpace(strings.TrimSuffix(body, "-"))
+		if trimAfter {
+			rest := strings.TrimLeft(src[pos:], " \t\r\n")
+			line += strings.Count(src[pos:len(src)-len(rest)], "\n")
+			pos = len(src) - len(rest)
+		}
+
+		switch src[start+1] {
+		case '{':
+			tokens = append(tokens, templateToken{kind: templateExpr, text: body, line: tagLine})
+		case '%':
+			tokens = append(tokens, templateToken{kind: templateStmt, text: body, line: tagLine})
+		}
+	}
+	return tokens, nil
+}
+
+// findTagEnd returns the index of the first closing delimiter in s that is outside brackets and
+// string literals, so that an expression such as {'a': {'b': 1}} can end with "}}". It returns -1
+// if there is none.
+func findTagEnd(s, closing string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth <= 0 && strings.HasPrefix(s[i:], closing) {
+				return i
+			}
+			depth--
+		case '\'', '"':
+			quote := string(c)
+			if strings.HasPrefix(s[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			j := i + len(quote)
+			for j < len(s) && !strings.HasPrefix(s[j:], quote) {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(s) {
+				return -1
+			}
+			i = j + len(quote) - 1
+		default:
+			if depth <= 0 && strings.HasPrefix(s[i:], closing) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseTemplate(src string) ([]templateNode, error) {
+	tokens, err := tokenizeTemplate(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &templateParser{tokens: tokens}
+	nodes, end, err := p.parseBlock()
+	if err != nil {
+		return nil, err
+	}
+	if end != nil {
+		return nil, templateErrorf(end.line, "unexpected {%% %s %%}", end.text)
+	}
+	return nodes, nil
+}
+
+type templateParser struct {
+	tokens []templateToken
+	pos    int
+}
+
+func (p *templateParser) parseExpr(src string, line int) (syntax.Expr, error) {
+	expr, err := scriptOptions.ParseExpr("template", src, 0)
+	if err != nil {
+		var syntaxErr syntax.Error
+		if errors.As(err, &syntaxErr) {
+			return nil, templateErrorf(line, "%s", syntaxErr.Msg)
+		}
+		return nil, templateErrorf(line, "%s", err)
+	}
+	return expr, nil
+}
+
+// parseBlock parses nodes up to the end of the template or to an elif, else, endif or endfor tag,
+// which it returns.
+func (p *templateParser) parseBlock() ([]templateNode, *templateToken, error) {
+	var nodes []templateNode
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		p.pos++
+		switch tok.kind {
+		case templateText:
+			nodes = append(nodes, &templateTextNode{text: tok.text})
+		case templateExpr:
+			node, err := p.parseExprTag(tok)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+		case templateStmt:
+			keyword, _, _ := strings.Cut(tok.text, " ")
+			var node templateNode
+			var err error
+			switch keyword {
+			case "if":
+				node, err = p.parseIf(tok)
+			case "for":
+				node, err = p.parseFor(tok)
+			case "set":
+				node, err = p.parseSet(tok)
+			case "elif", "else", "endif", "endfor":
+				return nodes, &tok, nil
+			default:
+				err = templateErrorf(tok.line, "unknown tag {%% %s %%}", keyword)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil, nil
+}
+
+// parseExprTag parses "expr | filter | ...". A trailing "| name" is a filter if name is a filter.
+func (p *templateParser) parseExprTag(tok templateToken) (templateNode, error) {
+	src := tok.text
+	var filters []string
+	for {
+		i := strings.LastIndex(src, "|")
+		if i < 0 {
+			break
+		}
+		name := strings.TrimSpace(src[i+1:])
+		if _, ok := templateFilters[name]; !ok {
+			break
+		}
+		filters = append([]string{name}, filters...)
+		src = src[:i]
+	}
+	if strings.TrimSpace(src) == "" {
+		return nil, templateErrorf(tok.line, "empty {{ }}")
+	}
+	expr, err := p.parseExpr(strings.TrimSpace(src), tok.line)
+	if err != nil {
+		return nil, err
+	}
+	return &templateExprNode{line: tok.line, expr: expr, filters: filters}, nil
+}
+
+func (p *templateParser) parseIf(tok templateToken) (templateNode, error) {
+	node := &templateIfNode{}
+	cond := strings.TrimSpace(strings.TrimPrefix(tok.text, "if"))
+	if cond == "" {
+		return nil, templateErrorf(tok.line, "{%% if %%} needs a condition")
+	}
+	branchLine := tok.line
+	for {
+		var expr syntax.Expr
+		if cond != "" {
+			var err error
+			if expr, err = p.parseExpr(cond, branchLine); err != nil {
+				return nil, err
+			}
+		}
+		body, end, err := p.parseBlock()
+		if err != nil {
+			return nil, err
+		}
+		node.branches = append(node.branches, templateBranch{line: branchLine, cond: expr, body: body})
+		if end == nil {
+			return nil, templateErrorf(tok.line, "{%% if %%} is not closed with {%% endif %%}")
+		}
+		keyword, rest, _ := strings.Cut(end.text, " ")
+		rest = strings.TrimSpace(rest)
+		switch {
+		case end.text == "endif":
+			return node, nil
+		case keyword == "elif" && expr != nil && rest != "":
+			cond, branchLine = rest, end.line
+		case end.text == "else" && expr != nil:
+			cond, branchLine = "", end.line
+		default:
+			return nil, templateErrorf(end.line, "unexpected {%% %s %%}", end.text)
+		}
+	}
+}
+
+func (p *templateParser) parseFor(tok templateToken) (templateNode, error) {
+	m := templateForPattern.FindStringSubmatch(tok.text)
+	if m == nil {
+		return nil, templateErrorf(tok.line, "want {%% for name in expr %%}")
+	}
+	node := &templateForNode{line: tok.line}
+	for _, target := range strings.Split(m[1], ",") {
+		target = strings.TrimSpace(target)
+		if !templateIdentifier.MatchString(target) {
+			return nil, templateErrorf(tok.line, "invalid loop variable %q", target)
+		}
+		node.targets = append(node.targets, target)
+	}
+	iter, err := p.parseExpr(m[2], tok.line)
+	if err != nil {
+		return nil, err
+	}
+	node.iter = iter
+	body, end, err := p.parseBlock()
+	if err != nil {
+		return nil, err
+	}
+	if end == nil {
+		return nil, templateErrorf(node.line, "{%% for %%} is not closed with {%% endfor %%}")
+	}
+	if end.text != "endfor" {
+		return nil, templateErrorf(end.line, "unexpected {%% %s %%}", end.text)
+	}
+	node.body = body
+	return node, nil
+}
+
+func (p *templateParser) parseSet(tok templateToken) (templateNode, error) {
+	m := templateSetPattern.FindStringSubmatch(tok.text)
+	if m == nil {
+		return nil, templateErrorf(tok.line, "want {%% set name = expr %%}")
+	}
+	expr, err := p.parseExpr(m[2], tok.line)
+	if err != nil {
+		return nil, err
+	}
+	return &templateSetNode{line: tok.line, name: m[1], expr: expr}, nil
+}
+
+// templateRenderer evaluates template nodes. Loop variables and {% set %} names are visible until
+// the end of the enclosing loop body.
+type templateRenderer struct {
+	thread *starlark.Thread
+	out    strings.Builder
+}
+
+func renderTemplate(thread *starlark.Thread, src string, globals starlark.StringDict) (string, error) {
+	nodes, err := parseTemplate(src)
+	if err != nil {
+		return "", err
+	}
+	env := make(starlark.StringDict, len(globals))
+	for k, v := range globals {
+		env[k] = v
+	}
+	r := &templateRenderer{thread: thread}
+	if err := r.render(nodes, env); err != nil {
+		return "", err
+	}
+	return r.out.String(), nil
+}
+
+func (r *templateRenderer) eval(expr syntax.Expr, line int, env starlark.StringDict) (starlark.Value, error) {
+	v, err := starlark.EvalExprOptions(scriptOptions, r.thread, expr, env)
+	if err != nil {
+		var resolveErrs resolve.ErrorList
+		if errors.As(err, &resolveErrs) && len(resolveErrs) > 0 {
+			return nil, templateErrorf(line, "%s", resolveErrs[0].Msg)
+		}
+		return nil, templateErrorf(line, "%s", err)
+	}
+	return v, nil
+}
+
+func (r *templateRenderer) render(nodes []templateNode, env starlark.StringDict) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *templateTextNode:
+			r.out.WriteString(n.text)
+		case *templateExprNode:
+			v, err := r.eval(n.expr, n.line, env)
+			if err != nil {
+				return err
+			}
+			text, err := r.applyFilters(v, n.filters)
+			if err != nil {
+				return templateErrorf(n.line, "%s", err)
+			}
+			r.out.WriteString(text)
+		case *templateSetNode:
+			v, err := r.eval(n.expr, n.line, env)
+			if err != nil {
+				return err
+			}
+			env[n.name] = v
+		case *templateIfNode:
+			for _, branch := range n.branches {
+				ok := true
+				if branch.cond != nil {
+					v, err := r.eval(branch.cond, branch.line, env)
+					if err != nil {
+						return err
+					}
+					ok = bool(v.Truth())
+				}
+				if ok {
+					if err := r.render(branch.body, env); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		case *templateForNode:
+			if err := r.renderFor(n, env); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *templateRenderer) renderFor(n *templateForNode, env starlark.StringDict) error {
+	v, err := r.eval(n.iter, n.line, env)
+	if err != nil {
+		return err
+	}
+	items, err := iterableValues(v)
+	if err != nil {
+		return templateErrorf(n.line, "for: %s", err)
+	}
+	scope := make(starlark.StringDict, len(env)+len(n.targets)+1)
+	for k, v := range env {
+		scope[k] = v
+	}
+	for i, item := range items {
+		if len(n.targets) == 1 {
+			scope[n.targets[0]] = item
+		} else {
+			seq, ok := item.(starlark.Indexable)
+			if !ok || seq.Len() != len(n.targets) {
+				return templateErrorf(n.line, "for: cannot unpack %s into %d variables", item.Type(), len(n.targets))
+			}
+			for j, target := range n.targets {
+				scope[target] = seq.Index(j)
+			}
+		}
+		scope["loop"] = starlarkstruct.FromStringDict(starlark.String("loop"), starlark.StringDict{
+			"index":  starlark.MakeInt(i + 1),
+			"index0": starlark.MakeInt(i),
+			"first":  starlark.Bool(i == 0),
+			"last":   starlark.Bool(i == len(items)-1),
+			"length": starlark.MakeInt(len(items)),
+		})
+		if err := r.render(n.body, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonWholeNumbers replaces the floats in v that have no fractional part with ints, so the json
+// filter writes Terraform's whole numbers as 8080 rather than 8080.0, as plain output does.
+func jsonWholeNumbers(v starlark.Value, path []starlark.Value) (starlark.Value, error) {
+	switch v := v.(type) {
+	case starlark.Float:
+		if f := float64(v); f == math.Trunc(f) && math.Abs(f) < 1e21 {
+			return starlark.NumberToInt(v)
+		}
+		return v, nil
+	case *starlark.List:
+		if err := checkCycle(v, path); err != nil {
+			return nil, err
+		}
+		elems := make([]starlark.Value, v.Len())
+		for i := range elems {
+			elem, err := jsonWholeNumbers(v.Index(i), append(path, v))
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return starlark.NewList(elems), nil
+	case starlark.Tuple:
+		elems := make(starlark.Tuple, len(v))
+		for i, x := range v {
+			elem, err := jsonWholeNumbers(x, path)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return elems, nil
+	case *starlark.Dict:
+		if err := checkCycle(v, path); err != nil {
+			return nil, err
+		}
+		out := starlark.NewDict(v.Len())
+		for _, item := range v.Items() {
+			val, err := jsonWholeNumbers(item[1], append(path, v))
+			if err != nil {
+				return nil, err
+			}
+			if err := out.SetKey(item[0], val); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func (r *templateRenderer) applyFilters(v starlark.Value, filters []string) (string, error) {
+	for _, name := range filters {
+		if name == "json" {
+			whole, err := jsonWholeNumbers(v, nil)
+			if err != nil {
+				return "", err
+			}
+			encoded, err := starlark.Call(r.thread, json.Module.Members["encode"], starlark.Tuple{whole}, nil)
+			if err != nil {
+				return "", err
+			}
+			v = encoded
+			continue
+		}
+		v = starlark.String(templateFilters[name](displayString(v)))
+	}
+	return displayString(v), nil
+}
@@ -0,0 +1,322 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// textModule implements the "text" module with case conversion, normalization and layout helpers.
+var textModule = &starlarkstruct.Module{
+	Name: "text",
+	Members: starlark.StringDict{
+		"snake_case":     starlark.NewBuiltin("text.snake_case", textCase),
+		"kebab_case":     starlark.NewBuiltin("text.kebab_case", textCase),
+		"camel_case":     starlark.NewBuiltin("text.camel_case", textCase),
+		"pascal_case":    starlark.NewBuiltin("text.pascal_case", textCase),
+		"slugify":        starlark.NewBuiltin("text.slugify", textSlugify),
+		"normalize":      starlark.NewBuiltin("text.normalize", textNormalize),
+		"pad_left":       starlark.NewBuiltin("text.pad_left", textPad),
+		"pad_right":      starlark.NewBuiltin("text.pad_right", textPad),
+		"wrap":           starlark.NewBuiltin("text.wrap", textWrap),
+		"markdown_table": starlark.NewBuiltin("text.markdown_table", textMarkdownTable),
+	},
+}
+
+// splitWords splits s into words at any character that is not a letter or digit, and at case
+// boundaries: "HTTPServerName" is "HTTP", "Server", "Name".
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	rs := []rune(s)
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// capitalize upper-cases the first letter of word and lower-cases the rest.
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + strings.ToLower(word[size:])
+}
+
+func textCase(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+	words := splitWords(s)
+	for i, w := range words {
+		switch b.Name() {
+		case "text.snake_case", "text.kebab_case":
+			words[i] = strings.ToLower(w)
+		case "text.camel_case":
+			if i == 0 {
+				words[i] = strings.ToLower(w)
+			} else {
+				words[i] = capitalize(w)
+			}
+		case "text.pascal_case":
+			words[i] = capitalize(w)
+		}
+	}
+	switch b.Name() {
+	case "text.snake_case":
+		return starlark.String(strings.Join(words, "_")), nil
+	case "text.kebab_case":
+		return starlark.String(strings.Join(words, "-")), nil
+	default:
+		return starlark.String(strings.Join(words, "")), nil
+	}
+}
+
+// slugReplacer transliterates letters that do not decompose into an ASCII letter and a mark.
+var slugReplacer = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "Æ", "AE", "œ", "oe", "Œ", "OE", "ø", "o", "Ø", "O",
+	"đ", "d", "Đ", "D", "ł", "l", "Ł", "L", "þ", "th", "Þ", "TH", "ð", "d", "Ð", "D",
+)
+
+// textSlugify returns a lower-case ASCII slug of s. Accents are removed, runs of other characters
+// become a single separator, and a slug longer than max_length is cut at a separator if possible.
+func textSlugify(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	sep := "-"
+	maxLength := 0
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "max_length?", &maxLength, "sep?", &sep); err != nil {
+		return nil, err
+	}
+	if maxLength < 0 {
+		return nil, fmt.Errorf("%s: max_length must not be negative", b.Name())
+	}
+
+	stripped, _, err := transform.String(transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn))), slugReplacer.Replace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	words := strings.FieldsFunc(strings.ToLower(stripped), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	slug := strings.Join(words, sep)
+
+	if maxLength > 0 && len(slug) > maxLength {
+		cut := slug[:maxLength]
+		if sep != "" && !strings.HasPrefix(slug[maxLength:], sep) {
+			if i := strings.LastIndex(cut, sep); i > 0 {
+				cut = cut[:i]
+			}
+		}
+		slug = strings.TrimSuffix(cut, sep)
+	}
+	return starlark.String(slug), nil
+}
+
+func textNormalize(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	form := "NFC"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "form?", &form); err != nil {
+		return nil, err
+	}
+	var f norm.Form
+	switch strings.ToUpper(form) {
+	case "NFC":
+		f = norm.NFC
+	case "NFD":
+		f = norm.NFD
+	case "NFKC":
+		f = norm.NFKC
+	case "NFKD":
+		f = norm.NFKD
+	default:
+		return nil, fmt.Errorf("%s: unknown form %q, want NFC, NFD, NFKC or NFKD", b.Name(), form)
+	}
+	return starlark.String(f.String(s)), nil
+}
+
+// textPad pads s to width characters with fill. Strings that are already wide enough are returned
+// unchanged.
+func textPad(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	var width int
+	fill := " "
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "width", &width, "fill?", &fill); err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(fill) != 1 {
+		return nil, fmt.Errorf("%s: fill must be a single character, got %q", b.Name(), fill)
+	}
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return starlark.String(s), nil
+	}
+	padding := strings.Repeat(fill, n)
+	if b.Name() == "text.pad_left" {
+		return starlark.String(padding + s), nil
+	}
+	return starlark.String(s + padding), nil
+}
+
+// textWrap returns the lines of s word-wrapped to width characters. Whitespace between words is
+// collapsed, and words longer than width are kept whole on their own line.
+func textWrap(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	width := 80
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "width?", &width); err != nil {
+		return nil, err
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("%s: width must be positive", b.Name())
+	}
+
+	var lines []starlark.Value
+	var line strings.Builder
+	lineWidth := 0
+	for _, word := range strings.Fields(s) {
+		n := utf8.RuneCountInString(word)
+		if lineWidth > 0 && lineWidth+1+n > width {
+			lines = append(lines, starlark.String(line.String()))
+			line.Reset()
+			lineWidth = 0
+		}
+		if lineWidth > 0 {
+			line.WriteByte(' ')
+			lineWidth++
+		}
+		line.WriteString(word)
+		lineWidth += n
+	}
+	if lineWidth > 0 {
+		lines = append(lines, starlark.String(line.String()))
+	}
+	return starlark.NewList(lines), nil
+}
+
+// textMarkdownTable renders a list of dicts as a GitHub-flavored markdown table. Columns default to
+// the keys of the rows in order of first appearance; missing and None values are left empty.
+func textMarkdownTable(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rows *starlark.List
+	var columns *starlark.List
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "rows", &rows, "columns?", &columns); err != nil {
+		return nil, err
+	}
+
+	var dicts []starlark.IterableMapping
+	var headers []string
+	seen := make(map[string]bool)
+	for i := 0; i < rows.Len(); i++ {
+		row, ok := rows.Index(i).(starlark.IterableMapping)
+		if !ok {
+			return nil, fmt.Errorf("%s: row %d: got %s, want dict", b.Name(), i, rows.Index(i).Type())
+		}
+		dicts = append(dicts, row)
+		if columns != nil {
+			continue
+		}
+		for _, item := range row.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("%s: row %d: dict keys must be strings, got %s", b.Name(), i, item[0].Type())
+			}
+			if !seen[string(k)] {
+				seen[string(k)] = true
+				headers = append(headers, string(k))
+			}
+		}
+	}
+	if columns != nil {
+		for i := 0; i < columns.Len(); i++ {
+			c, ok := starlark.AsString(columns.Index(i))
+			if !ok {
+				return nil, fmt.Errorf("%s: columns: got %s, want string", b.Name(), columns.Index(i).Type())
+			}
+			headers = append(headers, c)
+		}
+	}
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("%s: no columns", b.Name())
+	}
+
+	cells := make([][]string, len(dicts)+1)
+	cells[0] = make([]string, len(headers))
+	widths := make([]int, len(headers))
+	for j, h := range headers {
+		cells[0][j] = markdownCell(h)
+		widths[j] = max(3, utf8.RuneCountInString(cells[0][j]))
+	}
+	for i, row := range dicts {
+		cells[i+1] = make([]string, len(headers))
+		for j, h := range headers {
+			v, found, err := row.Get(starlark.String(h))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", b.Name(), err)
+			}
+			var text string
+			if found && v != starlark.None {
+				if s, ok := starlark.AsString(v); ok {
+					text = s
+				} else {
+					text = displayString(v)
+				}
+			}
+			cells[i+1][j] = markdownCell(text)
+			widths[j] = max(widths[j], utf8.RuneCountInString(cells[i+1][j]))
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for j, cell := range row {
+			sb.WriteString(" ")
+			sb.WriteString(cell)
+			sb.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+			sb.WriteString(" |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(cells[0])
+	separator := make([]string, len(headers))
+	for j := range separator {
+		separator[j] = strings.Repeat("-", widths[j])
+	}
+	writeRow(separator)
+	for _, row := range cells[1:] {
+		writeRow(row)
+	}
+	return starlark.String(sb.String()), nil
+}
+
+// markdownCell escapes pipes and replaces line breaks so that text fits in a single table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
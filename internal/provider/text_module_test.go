@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTextModule_case(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval(
+						<<-EOT
+						result = {
+						    "snake": text.snake_case("HTTPServerName"),
+						    "kebab": text.kebab_case("getHTTPResponseCode"),
+						    "camel": text.camel_case("get_http_response_code"),
+						    "pascal": text.pascal_case("my web-app"),
+						}
+						EOT
+						,
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("test", map[string]interface{}{
+						"snake":  "http_server_name",
+						"kebab":  "get-http-response-code",
+						"camel":  "getHttpResponseCode",
+						"pascal": "MyWebApp",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTextModule_slugifyAndNormalize(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "slugify" {
+					value = provider::starlark::eval("result = text.slugify('Crème Brûlée & Straße!')", {})
+				}
+				output "slugify_max_length" {
+					value = provider::starlark::eval("result = text.slugify('Hello, World: the sequel', max_length=18)", {})
+				}
+				output "normalize" {
+					value = provider::starlark::eval("result = text.normalize('e\\u0301') == '\\u00e9'", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("slugify", "creme-brulee-strasse"),
+					resource.TestCheckOutput("slugify_max_length", "hello-world-the"),
+					resource.TestCheckOutput("normalize", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTextModule_layout(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "pad" {
+					value = provider::starlark::eval("result = [text.pad_left('7', 3, '0'), text.pad_right('ab', 5, '.')]", {})
+				}
+				output "wrap" {
+					value = provider::starlark::eval("result = text.wrap('The quick brown fox jumps over the lazy dog', 12)", {})
+				}
+				output "markdown_table" {
+					value = provider::starlark::eval("result = text.markdown_table(rows)", {
+						rows = [{ name = "web", size = 2 }, { name = "db", size = null }, { name = "cache", size = 0.5 }]
+					})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("pad", []interface{}{"007", "ab..."}),
+					NewTestCheckOutput("wrap", []interface{}{"The quick", "brown fox", "jumps over", "the lazy dog"}),
+					resource.TestCheckOutput("markdown_table", "| name  | size |\n| ----- | ---- |\n| web   | 2    |\n| db    |      |\n| cache | 0.5  |\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTextModule_invalidFill(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = text.pad_left('x', 3, 'ab')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`text.pad_left: fill must be a single character`),
+			},
+		},
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// tfFunctions are the Terraform functions exposed by the "tf" module, keyed by their Terraform name.
+//
+// Functions that depend on the wall clock, the filesystem or randomness, such as timestamp() or
+// uuid(), are deliberately left out so scripts stay deterministic.
+var tfFunctions = map[string]function.Function{
+	"abs":             stdlib.AbsoluteFunc,
+	"alltrue":         allTrueFunc,
+	"anytrue":         anyTrueFunc,
+	"ceil":            stdlib.CeilFunc,
+	"chomp":           stdlib.ChompFunc,
+	"chunklist":       stdlib.ChunklistFunc,
+	"cidrhost":        cidrHostFunc,
+	"cidrnetmask":     cidrNetmaskFunc,
+	"cidrsubnet":      cidrSubnetFunc,
+	"cidrsubnets":     cidrSubnetsFunc,
+	"coalesce":        coalesceFunc,
+	"coalescelist":    stdlib.CoalesceListFunc,
+	"compact":         stdlib.CompactFunc,
+	"concat":          stdlib.ConcatFunc,
+	"contains":        stdlib.ContainsFunc,
+	"csvdecode":       stdlib.CSVDecodeFunc,
+	"distinct":        stdlib.DistinctFunc,
+	"element":         stdlib.ElementFunc,
+	"endswith":        endsWithFunc,
+	"flatten":         stdlib.FlattenFunc,
+	"floor":           stdlib.FloorFunc,
+	"format":          stdlib.FormatFunc,
+	"formatdate":      stdlib.FormatDateFunc,
+	"formatlist":      stdlib.FormatListFunc,
+	"indent":          stdlib.IndentFunc,
+	"index":           indexFunc,
+	"join":            stdlib.JoinFunc,
+	"jsondecode":      stdlib.JSONDecodeFunc,
+	"jsonencode":      stdlib.JSONEncodeFunc,
+	"keys":            stdlib.KeysFunc,
+	"length":          lengthFunc,
+	"log":             stdlib.LogFunc,
+	"lookup":          stdlib.LookupFunc,
+	"lower":           stdlib.LowerFunc,
+	"max":             stdlib.MaxFunc,
+	"merge":           stdlib.MergeFunc,
+	"min":             stdlib.MinFunc,
+	"one":             oneFunc,
+	"parseint":        stdlib.ParseIntFunc,
+	"pow":             stdlib.PowFunc,
+	"range":           stdlib.RangeFunc,
+	"regex":           stdlib.RegexFunc,
+	"regexall":        stdlib.RegexAllFunc,
+	"replace":         replaceFunc,
+	"reverse":         stdlib.ReverseListFunc,
+	"setintersection": stdlib.SetIntersectionFunc,
+	"setproduct":      stdlib.SetProductFunc,
+	"setsubtract":     stdlib.SetSubtractFunc,
+	"setunion":        stdlib.SetUnionFunc,
+	"signum":          stdlib.SignumFunc,
+	"slice":           stdlib.SliceFunc,
+	"sort":            stdlib.SortFunc,
+	"split":           stdlib.SplitFunc,
+	"startswith":      startsWithFunc,
+	"strrev":          stdlib.ReverseFunc,
+	"substr":          stdlib.SubstrFunc,
+	"sum":             sumFunc,
+	"timeadd":         stdlib.TimeAddFunc,
+	"timecmp":         timeCmpFunc,
+	"title":           stdlib.TitleFunc,
+	"trim":            stdlib.TrimFunc,
+	"trimprefix":      stdlib.TrimPrefixFunc,
+	"trimspace":       stdlib.TrimSpaceFunc,
+	"trimsuffix":      stdlib.TrimSuffixFunc,
+	"upper":           stdlib.UpperFunc,
+	"values":          stdlib.ValuesFunc,
+	"zipmap":          stdlib.ZipmapFunc,
+}
+
+// replaceFunc matches Terraform's replace(), which treats a substring wrapped in slashes as a regular expression.
+var replaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "substr", Type: cty.String},
+		{Name: "replace", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		substr := args[1].AsString()
+		if len(substr) > 1 && strings.HasPrefix(substr, "/") && strings.HasSuffix(substr, "/") {
+			return stdlib.RegexReplace(args[0], cty.StringVal(substr[1:len(substr)-1]), args[2])
+		}
+		return stdlib.Replace(args[0], args[1], args[2])
+	},
+})
+
+// tfModule implements the "tf" module.
+var tfModule = newTFModule()
+
+func newTFModule() *starlarkstruct.Module {
+	members := make(starlark.StringDict, len(tfFunctions))
+	for name, fn := range tfFunctions {
+		members[name] = starlark.NewBuiltin("tf."+name, tfFunctionBuiltin(fn))
+	}
+	return &starlarkstruct.Module{Name: "tf", Members: members}
+}
+
+// tfFunctionBuiltin adapts a cty function to a Starlark builtin. Arguments are converted to the
+// parameter types the same way HCL converts them before a function call.
+func tfFunctionBuiltin(fn function.Function) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf("%s: unexpected keyword arguments", b.Name())
+		}
+
+		params := fn.Params()
+		varParam := fn.VarParam()
+		if len(args) < len(params) || (varParam == nil && len(args) > len(params)) {
+			return nil, fmt.Errorf("%s: got %d arguments, want %d", b.Name(), len(args), len(params))
+		}
+
+		ctyArgs := make([]cty.Value, 0, len(args))
+		for i, arg := range args {
+			param := varParam
+			if i < len(params) {
+				param = &params[i]
+			}
+
+			val, err := starlarkToCty(arg)
+			if err != nil {
+				return nil, fmt.Errorf("%s: argument %d: %s", b.Name(), i+1, err)
+			}
+			if !param.Type.Equals(cty.DynamicPseudoType) {
+				val, err = convert.Convert(val, param.Type)
+				if err != nil {
+					return nil, fmt.Errorf("%s: argument %d: %s", b.Name(), i+1, err)
+				}
+			}
+			ctyArgs = append(ctyArgs, val)
+		}
+
+		result, err := fn.Call(ctyArgs)
+		if err != nil {
+			var argErr function.ArgError
+			if errors.As(err, &argErr) {
+				return nil, fmt.Errorf("%s: argument %d: %s", b.Name(), argErr.Index+1, argErr.Error())
+			}
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+		conv, err := ctyToStarlark(result)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+		return conv, nil
+	}
+}
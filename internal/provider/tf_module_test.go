@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTfModule_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "formatdate" {
+					value = provider::starlark::eval("result = tf.formatdate('YYYY-MM-DD', v)", { v = "2024-03-05T10:00:00Z" })
+				}
+				output "regexall" {
+					value = provider::starlark::eval("result = tf.regexall('[a-z]+', v)", { v = "1234abcd5678efgh9" })
+				}
+				output "setproduct" {
+					value = provider::starlark::eval("result = [e + '-' + r for e, r in tf.setproduct(v, ['eu', 'us'])]", { v = ["dev", "prod"] })
+				}
+				output "merge" {
+					value = provider::starlark::eval("result = tf.merge(v, {'b': 'y'})", { v = { a = "x", b = "z" } })
+				}
+				output "coalesce" {
+					value = provider::starlark::eval("result = [tf.coalesce(None, v, 'x'), tf.coalesce('', 'x'), tf.coalesce(None, 1, 2)]", { v = "" })
+				}
+				output "replace" {
+					value = provider::starlark::eval("result = tf.replace(v, '/w.*d/', 'there')", { v = "hello world" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("formatdate", "2024-03-05"),
+					NewTestCheckOutput("regexall", []interface{}{"abcd", "efgh"}),
+					NewTestCheckOutput("setproduct", []interface{}{"dev-eu", "dev-us", "prod-eu", "prod-us"}),
+					NewTestCheckOutput("merge", map[string]interface{}{"a": "x", "b": "y"}),
+					NewTestCheckOutput("coalesce", []interface{}{"x", "x", json.Number("1")}),
+					resource.TestCheckOutput("replace", "hello there"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTfModule_collections(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "length" {
+					value = provider::starlark::eval("result = [tf.length(v), tf.length('héllo'), tf.length({'a': 1})]", { v = ["a", "b"] })
+				}
+				output "sum" {
+					value = provider::starlark::eval("result = tf.sum(v)", { v = [1, 2.5, 3] })
+				}
+				output "index" {
+					value = provider::starlark::eval("result = tf.index(v, 'b')", { v = ["a", "b", "c"] })
+				}
+				output "one" {
+					value = provider::starlark::eval("result = [tf.one(v), tf.one([])]", { v = ["x"] })
+				}
+				output "alltrue_anytrue" {
+					value = provider::starlark::eval("result = [tf.alltrue(v), tf.anytrue(v), tf.alltrue([])]", { v = [true, false] })
+				}
+				output "startswith_endswith" {
+					value = provider::starlark::eval("result = [tf.startswith(v, 'he'), tf.endswith(v, 'x')]", { v = "hello" })
+				}
+				output "timecmp" {
+					value = provider::starlark::eval("result = [tf.timecmp(v, '2024-01-01T01:00:00+01:00'), tf.timecmp(v, '2024-01-02T00:00:00Z')]", { v = "2024-01-01T00:00:00Z" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("length", []interface{}{json.Number("2"), json.Number("5"), json.Number("1")}),
+					resource.TestCheckOutput("sum", "6.5"),
+					resource.TestCheckOutput("index", "1"),
+					NewTestCheckOutput("one", []interface{}{"x", nil}),
+					NewTestCheckOutput("alltrue_anytrue", []interface{}{false, true, true}),
+					NewTestCheckOutput("startswith_endswith", []interface{}{true, false}),
+					NewTestCheckOutput("timecmp", []interface{}{json.Number("0"), json.Number("-1")}),
+				),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = tf.index(['a'], 'z')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`tf.index: item not found`),
+			},
+		},
+	})
+}
+
+func TestAccTfModule_cidr(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "cidrsubnet" {
+					value = provider::starlark::eval("result = tf.cidrsubnet(v, 8, 2)", { v = "10.0.0.0/16" })
+				}
+				output "cidrsubnets" {
+					value = provider::starlark::eval("result = tf.cidrsubnets(v, 4, 4, 8, 4)", { v = "10.1.0.0/16" })
+				}
+				output "cidrhost" {
+					value = provider::starlark::eval("result = tf.cidrhost(v, 16)", { v = "10.12.112.0/20" })
+				}
+				output "cidrnetmask" {
+					value = provider::starlark::eval("result = tf.cidrnetmask(v)", { v = "172.16.0.0/12" })
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("cidrsubnet", "10.0.2.0/24"),
+					NewTestCheckOutput("cidrsubnets", []interface{}{"10.1.0.0/20", "10.1.16.0/20", "10.1.32.0/24", "10.1.48.0/20"}),
+					resource.TestCheckOutput("cidrhost", "10.12.112.16"),
+					resource.TestCheckOutput("cidrnetmask", "255.240.0.0"),
+				),
+			},
+		},
+	})
+}
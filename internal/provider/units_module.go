@@ -0,0 +1,439 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// unitsModule implements the "units" module for byte sizes, durations and bit rates.
+var unitsModule = &starlarkstruct.Module{
+	Name: "units",
+	Members: starlark.StringDict{
+		"parse_bytes":     starlark.NewBuiltin("units.parse_bytes", unitsParseBytes),
+		"parse_duration":  starlark.NewBuiltin("units.parse_duration", unitsParseDuration),
+		"parse_rate":      starlark.NewBuiltin("units.parse_rate", unitsParseRate),
+		"format_bytes":    starlark.NewBuiltin("units.format_bytes", unitsFormatBytes),
+		"format_duration": starlark.NewBuiltin("units.format_duration", unitsFormatDuration),
+		"format_rate":     starlark.NewBuiltin("units.format_rate", unitsFormatRate),
+	},
+}
+
+var quantityPattern = regexp.MustCompile(`^([+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?)\s*([A-Za-zµ/]*)$`)
+
+func ratPow(base, exp int64) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil))
+}
+
+var (
+	decimalPrefixes = map[string]*big.Rat{"k": ratPow(10, 3), "M": ratPow(10, 6), "G": ratPow(10, 9), "T": ratPow(10, 12), "P": ratPow(10, 15), "E": ratPow(10, 18)}
+	binaryPrefixes  = map[string]*big.Rat{"Ki": ratPow(2, 10), "Mi": ratPow(2, 20), "Gi": ratPow(2, 30), "Ti": ratPow(2, 40), "Pi": ratPow(2, 50), "Ei": ratPow(2, 60)}
+
+	binaryByteUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	decimalByteUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	rateUnits        = []string{"bps", "kbps", "Mbps", "Gbps", "Tbps", "Pbps"}
+
+	durationUnits = map[string]*big.Rat{
+		"ns": big.NewRat(1, 1e9), "us": big.NewRat(1, 1e6), "µs": big.NewRat(1, 1e6), "ms": big.NewRat(1, 1e3),
+		"s": big.NewRat(1, 1), "m": big.NewRat(60, 1), "h": big.NewRat(3600, 1), "d": big.NewRat(86400, 1), "w": big.NewRat(604800, 1),
+	}
+)
+
+// parseQuantity splits s into an exact number and a unit.
+func parseQuantity(s string) (*big.Rat, string, error) {
+	m := quantityPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, "", fmt.Errorf("invalid quantity %q", s)
+	}
+	n, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return nil, "", fmt.Errorf("invalid number in %q", s)
+	}
+	return n, m[2], nil
+}
+
+// prefixMultiplier returns the multiplier of an SI or IEC prefix. A bare SI prefix is ambiguous for
+// bytes, since tools disagree on whether "M" is 10^6 or 2^20, so it needs a convention.
+func prefixMultiplier(prefix, convention string, ambiguous bool) (*big.Rat, error) {
+	if prefix == "" {
+		return big.NewRat(1, 1), nil
+	}
+	if m, ok := binaryPrefixes[prefix]; ok {
+		return m, nil
+	}
+	if prefix == "K" {
+		prefix = "k"
+	}
+	m, ok := decimalPrefixes[prefix]
+	if !ok {
+		return nil, fmt.Errorf("unknown prefix %q", prefix)
+	}
+	if !ambiguous {
+		return m, nil
+	}
+	switch convention {
+	case "decimal":
+		return m, nil
+	case "binary":
+		return binaryPrefixes[strings.ToUpper(prefix)+"i"], nil
+	case "":
+		return nil, fmt.Errorf("%q is ambiguous, use %sB or %siB, or pass convention = \"decimal\" or \"binary\"", prefix, prefix, strings.ToUpper(prefix))
+	}
+	return nil, fmt.Errorf("unknown convention %q, want decimal or binary", convention)
+}
+
+// byteMultiplier returns the number of bytes in a unit such as "MB", "MiB", "Mi" or "B". Byte units
+// are case-insensitive, since "mb" can only mean megabytes.
+func byteMultiplier(unit, convention string) (*big.Rat, error) {
+	u := strings.ToUpper(unit)
+	switch {
+	case u == "" || u == "B":
+		return big.NewRat(1, 1), nil
+	case strings.HasSuffix(u, "IB"):
+		return prefixMultiplier(u[:len(u)-2]+"i", "", false)
+	case strings.HasSuffix(u, "I"):
+		return prefixMultiplier(u[:len(u)-1]+"i", "", false)
+	case strings.HasSuffix(u, "B"):
+		return prefixMultiplier(u[:len(u)-1], "", false)
+	default:
+		return prefixMultiplier(u, convention, true)
+	}
+}
+
+// rateMultiplier returns the number of bits per second in a unit such as "Mbps", "Mbit/s", "MB/s"
+// or "MBps". A lower-case b is bits and an upper-case B is bytes.
+func rateMultiplier(unit string) (*big.Rat, error) {
+	if unit == "" {
+		return big.NewRat(1, 1), nil
+	}
+	for _, suffix := range []struct {
+		text string
+		bits int64
+	}{{"bps", 1}, {"bit/s", 1}, {"b/s", 1}, {"Bps", 8}, {"B/s", 8}} {
+		if prefix, ok := strings.CutSuffix(unit, suffix.text); ok {
+			m, err := prefixMultiplier(prefix, "", false)
+			if err != nil {
+				return nil, fmt.Errorf("unknown rate unit %q", unit)
+			}
+			return new(big.Rat).Mul(m, big.NewRat(suffix.bits, 1)), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown rate unit %q, want a unit such as Mbps, Mbit/s or MB/s", unit)
+}
+
+func durationMultiplier(unit string) (*big.Rat, error) {
+	m, ok := durationUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("unknown duration unit %q, want ns, us, ms, s, m, h, d or w", unit)
+	}
+	return m, nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^([+-])?P(?:([\d.,]+)Y)?(?:([\d.,]+)M)?(?:([\d.,]+)W)?(?:([\d.,]+)D)?(?:T(?:([\d.,]+)H)?(?:([\d.,]+)M)?(?:([\d.,]+)S)?)?$`)
+
+// parseISODuration parses an ISO 8601 duration such as "P1DT12H" into seconds. Years and months
+// have no fixed length and are rejected; a day is 24 hours.
+func parseISODuration(s string) (*big.Rat, error) {
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return nil, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	if m[2] != "" || m[3] != "" {
+		return nil, fmt.Errorf("%q has years or months, which have no fixed length", s)
+	}
+	seconds := new(big.Rat)
+	for i, unit := range []int64{604800, 86400, 3600, 60, 1} {
+		text := m[i+4]
+		if text == "" {
+			continue
+		}
+		n, ok := new(big.Rat).SetString(strings.ReplaceAll(text, ",", "."))
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q in %q", text, s)
+		}
+		seconds.Add(seconds, n.Mul(n, big.NewRat(unit, 1)))
+	}
+	if m[1] == "-" {
+		seconds.Neg(seconds)
+	}
+	return seconds, nil
+}
+
+// parseDurationSeconds parses a Go duration such as "1h30m" or an ISO 8601 duration such as
+// "PT1H30M" into seconds.
+func parseDurationSeconds(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.TrimLeft(s, "+-"), "P") {
+		return parseISODuration(s)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewRat(int64(d), 1e9), nil
+}
+
+// ratValue returns r as an int if it is whole, otherwise as a float.
+func ratValue(r *big.Rat) starlark.Value {
+	if r.IsInt() {
+		return starlark.MakeBigInt(r.Num())
+	}
+	f, _ := r.Float64()
+	return starlark.Float(f)
+}
+
+func toRat(v starlark.Value) (*big.Rat, error) {
+	d, err := toDecimal(v)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).SetFrac(d.unscaled, pow10(d.scale)), nil
+}
+
+// formatRat formats r rounded to precision decimal places, without trailing zeros.
+func formatRat(r *big.Rat, precision int) string {
+	s := r.FloatString(precision)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// autoUnit returns the largest unit of units, each 1000 or 1024 times the previous, that |n| fills.
+func autoUnit(n *big.Rat, units []string, multiplier func(string) *big.Rat) string {
+	abs := new(big.Rat).Abs(n)
+	unit := units[0]
+	for _, u := range units[1:] {
+		if abs.Cmp(multiplier(u)) < 0 {
+			break
+		}
+		unit = u
+	}
+	return unit
+}
+
+func unitsParseBytes(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s, convention string
+	unit := "B"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "unit?", &unit, "convention?", &convention); err != nil {
+		return nil, err
+	}
+	n, u, err := parseQuantity(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("%s: %q is negative", b.Name(), s)
+	}
+	from, err := byteMultiplier(u, convention)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	to, err := byteMultiplier(unit, convention)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unit: %s", b.Name(), err)
+	}
+	bytes := n.Mul(n, from)
+	if !bytes.IsInt() {
+		return nil, fmt.Errorf("%s: %q is not a whole number of bytes", b.Name(), s)
+	}
+	return ratValue(bytes.Quo(bytes, to)), nil
+}
+
+func unitsParseDuration(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	unit := "s"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "unit?", &unit); err != nil {
+		return nil, err
+	}
+	to, err := durationMultiplier(unit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	seconds, err := parseDurationSeconds(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return ratValue(seconds.Quo(seconds, to)), nil
+}
+
+func unitsParseRate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	unit := "bps"
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s, "unit?", &unit); err != nil {
+		return nil, err
+	}
+	n, u, err := parseQuantity(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("%s: %q is negative", b.Name(), s)
+	}
+	if u != "" && !strings.Contains(u, "/") && !strings.HasSuffix(strings.ToLower(u), "ps") {
+		return nil, fmt.Errorf("%s: %q is ambiguous, use bits (%sbps) or bytes (%sB/s)", b.Name(), s, u, u)
+	}
+	from, err := rateMultiplier(u)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	to, err := rateMultiplier(unit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unit: %s", b.Name(), err)
+	}
+	bits := n.Mul(n, from)
+	return ratValue(bits.Quo(bits, to)), nil
+}
+
+// unitsFormatBytes formats a number of bytes in unit, or in the largest binary or decimal unit that
+// the value fills.
+func unitsFormatBytes(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	var unit string
+	convention := "binary"
+	precision := 2
+	sep := " "
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "n", &x, "unit?", &unit, "convention?", &convention, "precision?", &precision, "sep?", &sep); err != nil {
+		return nil, err
+	}
+	n, err := toRat(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if precision < 0 {
+		return nil, fmt.Errorf("%s: precision must not be negative", b.Name())
+	}
+	if unit == "" {
+		units := binaryByteUnits
+		switch convention {
+		case "binary":
+		case "decimal":
+			units = decimalByteUnits
+		default:
+			return nil, fmt.Errorf("%s: unknown convention %q, want decimal or binary", b.Name(), convention)
+		}
+		unit = autoUnit(n, units, func(u string) *big.Rat {
+			m, _ := byteMultiplier(u, "")
+			return m
+		})
+	}
+	m, err := byteMultiplier(unit, convention)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unit: %s", b.Name(), err)
+	}
+	return starlark.String(formatRat(n.Quo(n, m), precision) + sep + unit), nil
+}
+
+// unitsFormatDuration formats a number of seconds as a Go or ISO 8601 duration, or as a number of
+// unit, such as "1.5h".
+func unitsFormatDuration(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	var unit string
+	style := "go"
+	precision := 2
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "seconds", &x, "unit?", &unit, "style?", &style, "precision?", &precision); err != nil {
+		return nil, err
+	}
+	seconds, err := toRat(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if precision < 0 {
+		return nil, fmt.Errorf("%s: precision must not be negative", b.Name())
+	}
+	if unit != "" {
+		m, err := durationMultiplier(unit)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", b.Name(), err)
+		}
+		return starlark.String(formatRat(seconds.Quo(seconds, m), precision) + unit), nil
+	}
+
+	ns := new(big.Rat).Mul(seconds, big.NewRat(1e9, 1))
+	nanos := new(big.Int).Quo(ns.Num(), ns.Denom())
+	if !nanos.IsInt64() {
+		return nil, fmt.Errorf("%s: %s seconds is out of range", b.Name(), formatRat(seconds, 9))
+	}
+	d := time.Duration(nanos.Int64())
+	switch style {
+	case "go":
+		return starlark.String(d.String()), nil
+	case "iso":
+		return starlark.String(formatISODuration(d)), nil
+	}
+	return nil, fmt.Errorf("%s: unknown style %q, want go or iso", b.Name(), style)
+}
+
+// formatISODuration formats d as an ISO 8601 duration with days, hours, minutes and seconds.
+func formatISODuration(d time.Duration) string {
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteByte('P')
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+		if d == 0 {
+			return sb.String()
+		}
+	}
+	sb.WriteByte('T')
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	if hours > 0 {
+		fmt.Fprintf(&sb, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&sb, "%dM", minutes)
+	}
+	if d > 0 || hours == 0 && minutes == 0 {
+		sb.WriteString(formatRat(big.NewRat(int64(d), 1e9), 9) + "S")
+	}
+	return sb.String()
+}
+
+// unitsFormatRate formats a number of bits per second in unit, or in the largest decimal unit that
+// the value fills.
+func unitsFormatRate(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	var unit string
+	precision := 2
+	sep := " "
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "bps", &x, "unit?", &unit, "precision?", &precision, "sep?", &sep); err != nil {
+		return nil, err
+	}
+	n, err := toRat(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if precision < 0 {
+		return nil, fmt.Errorf("%s: precision must not be negative", b.Name())
+	}
+	if unit == "" {
+		unit = autoUnit(n, rateUnits, func(u string) *big.Rat {
+			m, _ := rateMultiplier(u)
+			return m
+		})
+	}
+	m, err := rateMultiplier(unit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unit: %s", b.Name(), err)
+	}
+	return starlark.String(formatRat(n.Quo(n, m), precision) + sep + unit), nil
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccUnitsModule_parse(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "bytes" {
+					value = provider::starlark::eval("result = [units.parse_bytes(s) for s in ['10Gi', '512MB', '1.5KiB', '2 gib', '100']]", {})
+				}
+				output "bytes_convention" {
+					value = provider::starlark::eval("result = [units.parse_bytes('10M', convention = 'binary'), units.parse_bytes('10M', convention = 'decimal')]", {})
+				}
+				output "bytes_unit" {
+					value = provider::starlark::eval("result = units.parse_bytes('10Gi', unit = 'MiB')", {})
+				}
+				output "durations" {
+					value = provider::starlark::eval("result = [units.parse_duration(s) for s in ['1h30m', 'P1D', 'PT1H30M', 'P1DT12H', '-PT0.5S']]", {})
+				}
+				output "duration_unit" {
+					value = provider::starlark::eval("result = units.parse_duration('300ms', unit = 'ms')", {})
+				}
+				output "rates" {
+					value = provider::starlark::eval("result = [units.parse_rate(s) for s in ['100Mbps', '1.5 Gbit/s', '10MB/s']]", {})
+				}
+				output "rate_unit" {
+					value = provider::starlark::eval("result = units.parse_rate('100Mbps', unit = 'Gbps')", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("bytes", []interface{}{json.Number("10737418240"), json.Number("512000000"), json.Number("1536"), json.Number("2147483648"), json.Number("100")}),
+					NewTestCheckOutput("bytes_convention", []interface{}{json.Number("10485760"), json.Number("10000000")}),
+					resource.TestCheckOutput("bytes_unit", "10240"),
+					NewTestCheckOutput("durations", []interface{}{json.Number("5400"), json.Number("86400"), json.Number("5400"), json.Number("129600"), json.Number("-0.5")}),
+					resource.TestCheckOutput("duration_unit", "300"),
+					NewTestCheckOutput("rates", []interface{}{json.Number("100000000"), json.Number("1500000000"), json.Number("80000000")}),
+					resource.TestCheckOutput("rate_unit", "0.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUnitsModule_format(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "bytes" {
+					value = provider::starlark::eval("result = [units.format_bytes(n) for n in [1023, 1536, 10737418240, 123456789]]", {})
+				}
+				output "bytes_options" {
+					value = provider::starlark::eval("result = [units.format_bytes(123456789, convention = 'decimal'), units.format_bytes(10737418240, unit = 'Gi', sep = '')]", {})
+				}
+				output "go" {
+					value = provider::starlark::eval("result = [units.format_duration(s) for s in [5400, 0.3, 90061.5]]", {})
+				}
+				output "iso" {
+					value = provider::starlark::eval("result = [units.format_duration(s, style = 'iso') for s in [5400, 86400, 90061.5, 0]]", {})
+				}
+				output "duration_unit" {
+					value = provider::starlark::eval("result = units.format_duration(5400, unit = 'h')", {})
+				}
+				output "rates" {
+					value = provider::starlark::eval("result = [units.format_rate(100000000), units.format_rate(1500000000), units.format_rate(100000000, unit = 'MB/s')]", {})
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("bytes", []interface{}{"1023 B", "1.5 KiB", "10 GiB", "117.74 MiB"}),
+					NewTestCheckOutput("bytes_options", []interface{}{"123.46 MB", "10Gi"}),
+					NewTestCheckOutput("go", []interface{}{"1h30m0s", "300ms", "25h1m1.5s"}),
+					NewTestCheckOutput("iso", []interface{}{"PT1H30M", "P1D", "P1DT1H1M1.5S", "PT0S"}),
+					resource.TestCheckOutput("duration_unit", "1.5h"),
+					NewTestCheckOutput("rates", []interface{}{"100 Mbps", "1.5 Gbps", "12.5 MB/s"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUnitsModule_ambiguous(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = units.parse_bytes('10M')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`is ambiguous`),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = units.parse_duration('P1M')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`no fixed length`),
+			},
+		},
+	})
+}
@@ -0,0 +1,551 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// xmlModule implements the "xml" module.
+//
+// Elements are represented as dicts with four keys: "tag", the element name including any namespace
+// prefix; "attrs", a dict of attribute name -> value; "text", the element's own text with surrounding
+// whitespace removed; and "children", a list of child elements. Comments and processing instructions
+// are dropped, and in mixed content the text segments are joined.
+var xmlModule = &starlarkstruct.Module{
+	Name: "xml",
+	Members: starlark.StringDict{
+		"parse":   starlark.NewBuiltin("xml.parse", xmlParse),
+		"encode":  starlark.NewBuiltin("xml.encode", xmlEncode),
+		"find":    starlark.NewBuiltin("xml.find", xmlFind),
+		"findall": starlark.NewBuiltin("xml.findall", xmlFindAll),
+	},
+}
+
+// xmlParse parses an XML document and returns its root element.
+func xmlParse(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+	root, err := parseXMLDocument(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return root, nil
+}
+
+type xmlElement struct {
+	name     string
+	attrs    *starlark.Dict
+	text     strings.Builder
+	children []starlark.Value
+}
+
+func (e *xmlElement) dict() *starlark.Dict {
+	d := starlark.NewDict(4)
+	_ = d.SetKey(starlark.String("tag"), starlark.String(e.name))
+	_ = d.SetKey(starlark.String("attrs"), e.attrs)
+	_ = d.SetKey(starlark.String("text"), starlark.String(strings.TrimSpace(e.text.String())))
+	_ = d.SetKey(starlark.String("children"), starlark.NewList(e.children))
+	return d
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
+
+func parseXMLDocument(s string) (*starlark.Dict, error) {
+	d := xml.NewDecoder(strings.NewReader(s))
+	var stack []*xmlElement
+	var root *starlark.Dict
+	for {
+		// RawToken keeps namespace prefixes as written, so encoding the result reproduces them.
+		tok, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				line, _ := d.InputPos()
+				return nil, fmt.Errorf("line %d: document has more than one root element", line)
+			}
+			attrs := starlark.NewDict(len(tok.Attr))
+			for _, a := range tok.Attr {
+				if err := attrs.SetKey(starlark.String(xmlName(a.Name)), starlark.String(a.Value)); err != nil {
+					return nil, err
+				}
+			}
+			stack = append(stack, &xmlElement{name: xmlName(tok.Name), attrs: attrs})
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].name != xmlName(tok.Name) {
+				line, _ := d.InputPos()
+				return nil, fmt.Errorf("line %d: unexpected end element </%s>", line, xmlName(tok.Name))
+			}
+			elem := stack[len(stack)-1].dict()
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = elem
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, elem)
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(tok)
+			} else if strings.TrimSpace(string(tok)) != "" {
+				line, _ := d.InputPos()
+				return nil, fmt.Errorf("line %d: text outside the root element", line)
+			}
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("element <%s> is not closed", stack[len(stack)-1].name)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("document has no root element")
+	}
+	return root, nil
+}
+
+// xmlEncode renders an element dict as indented XML. Attributes are written in sorted order.
+func xmlEncode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var elem starlark.Value
+	indent := 2
+	declaration := true
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "element", &elem, "indent?", &indent, "declaration?", &declaration); err != nil {
+		return nil, err
+	}
+	if indent < 0 {
+		return nil, fmt.Errorf("%s: indent must not be negative", b.Name())
+	}
+
+	var buf strings.Builder
+	if declaration {
+		buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	}
+	if err := writeXMLElement(&buf, elem, strings.Repeat(" ", indent), 0); err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(buf.String()), nil
+}
+
+var xmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func writeXMLElement(buf *strings.Builder, v starlark.Value, indent string, depth int) error {
+	e, err := xmlElementParts(v)
+	if err != nil {
+		return err
+	}
+	if e.tag == "" {
+		return fmt.Errorf("element has no tag")
+	}
+
+	prefix := strings.Repeat(indent, depth)
+	buf.WriteString(prefix + "<" + e.tag)
+	for _, a := range e.attrs {
+		buf.WriteString(" " + a.key + `="`)
+		if err := xml.EscapeText(buf, []byte(a.value)); err != nil {
+			return err
+		}
+		buf.WriteString(`"`)
+	}
+
+	switch {
+	case len(e.children) == 0 && e.text == "":
+		buf.WriteString("/>\n")
+	case len(e.children) == 0:
+		buf.WriteString(">" + xmlTextEscaper.Replace(e.text) + "</" + e.tag + ">\n")
+	default:
+		buf.WriteString(">\n")
+		if e.text != "" {
+			buf.WriteString(prefix + indent + xmlTextEscaper.Replace(e.text) + "\n")
+		}
+		for i, child := range e.children {
+			if err := writeXMLElement(buf, child, indent, depth+1); err != nil {
+				return fmt.Errorf("<%s> child %d: %s", e.tag, i, err)
+			}
+		}
+		buf.WriteString(prefix + "</" + e.tag + ">\n")
+	}
+	return nil
+}
+
+type xmlParts struct {
+	tag      string
+	attrs    []stringPair
+	text     string
+	children []starlark.Value
+}
+
+type stringPair struct {
+	key, value string
+}
+
+// xmlElementParts reads the fields of an element dict. Missing "attrs", "text" and "children" are treated as empty.
+func xmlElementParts(v starlark.Value) (*xmlParts, error) {
+	d, ok := v.(*starlark.Dict)
+	if !ok {
+		return nil, fmt.Errorf("got %s, want element dict", v.Type())
+	}
+	var e xmlParts
+
+	if tag, found, _ := d.Get(starlark.String("tag")); found {
+		s, ok := starlark.AsString(tag)
+		if !ok {
+			return nil, fmt.Errorf("tag must be a string, got %s", tag.Type())
+		}
+		e.tag = s
+	}
+
+	if text, found, _ := d.Get(starlark.String("text")); found && text != starlark.None {
+		s, ok := starlark.AsString(text)
+		if !ok {
+			return nil, fmt.Errorf("text must be a string, got %s", text.Type())
+		}
+		e.text = s
+	}
+
+	if attrs, found, _ := d.Get(starlark.String("attrs")); found && attrs != starlark.None {
+		m, ok := attrs.(starlark.IterableMapping)
+		if !ok {
+			return nil, fmt.Errorf("attrs must be a dict, got %s", attrs.Type())
+		}
+		for _, item := range m.Items() {
+			k, ok := starlark.AsString(item[0])
+			if !ok {
+				return nil, fmt.Errorf("attribute names must be strings, got %s", item[0].Type())
+			}
+			var value string
+			switch x := item[1].(type) {
+			case starlark.String:
+				value = string(x)
+			case starlark.Int, starlark.Float:
+				value = displayString(x)
+			case starlark.Bool:
+				value = strconv.FormatBool(bool(x))
+			default:
+				return nil, fmt.Errorf("attribute %q must be a string, got %s", k, item[1].Type())
+			}
+			e.attrs = append(e.attrs, stringPair{key: k, value: value})
+		}
+		sort.Slice(e.attrs, func(i, j int) bool { return e.attrs[i].key < e.attrs[j].key })
+	}
+
+	if children, found, _ := d.Get(starlark.String("children")); found && children != starlark.None {
+		l, ok := children.(starlark.Indexable)
+		if !ok {
+			return nil, fmt.Errorf("children must be a list, got %s", children.Type())
+		}
+		for i := 0; i < l.Len(); i++ {
+			e.children = append(e.children, l.Index(i))
+		}
+	}
+	return &e, nil
+}
+
+// xmlFind returns the first match of path under element, or None.
+func xmlFind(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var elem starlark.Value
+	var path string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "element", &elem, "path", &path); err != nil {
+		return nil, err
+	}
+	matches, err := xmlSelect(elem, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	if len(matches) == 0 {
+		return starlark.None, nil
+	}
+	return matches[0], nil
+}
+
+// xmlFindAll returns all matches of path under element, in document order.
+func xmlFindAll(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var elem starlark.Value
+	var path string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "element", &elem, "path", &path); err != nil {
+		return nil, err
+	}
+	matches, err := xmlSelect(elem, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.NewList(matches), nil
+}
+
+// xmlStep is one step of a path such as "//add[@key='x']/@value".
+type xmlStep struct {
+	descendant bool   // the step was preceded by "//"
+	name       string // a tag, "*", ".", "@attr" or "text()"
+	predicates []xmlPredicate
+}
+
+// xmlPredicate is a filter such as [2], [@key], [@key='x'], [child] or [child='x'].
+type xmlPredicate struct {
+	position int
+	attr     bool
+	name     string
+	value    *string
+}
+
+// xmlSelect evaluates a small subset of XPath against an element dict. Paths are relative to the
+// element unless they start with "/", in which case the first step matches the element itself.
+func xmlSelect(root starlark.Value, path string) ([]starlark.Value, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	steps, err := parseXMLPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []starlark.Value{root}
+	if strings.HasPrefix(path, "/") {
+		// Start from a virtual document node whose only child is the element.
+		doc := starlark.NewDict(1)
+		_ = doc.SetKey(starlark.String("children"), starlark.NewList([]starlark.Value{root}))
+		nodes = []starlark.Value{doc}
+	}
+
+	for i, step := range steps {
+		if step.descendant {
+			var expanded []starlark.Value
+			for _, n := range nodes {
+				if err := xmlDescendantsOrSelf(n, &expanded); err != nil {
+					return nil, err
+				}
+			}
+			nodes = expanded
+		}
+
+		terminal := strings.HasPrefix(step.name, "@") || step.name == "text()"
+		if terminal && i != len(steps)-1 {
+			return nil, fmt.Errorf("%s must be the last step of the path", step.name)
+		}
+
+		var next []starlark.Value
+		for _, n := range nodes {
+			e, err := xmlElementParts(n)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case step.name == "text()":
+				next = append(next, starlark.String(e.text))
+			case strings.HasPrefix(step.name, "@"):
+				for _, a := range e.attrs {
+					if a.key == step.name[1:] {
+						next = append(next, starlark.String(a.value))
+					}
+				}
+			case step.name == ".":
+				matched, err := filterXMLPredicates([]starlark.Value{n}, step.predicates)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, matched...)
+			default:
+				var candidates []starlark.Value
+				for _, child := range e.children {
+					c, err := xmlElementParts(child)
+					if err != nil {
+						return nil, err
+					}
+					if step.name == "*" || c.tag == step.name {
+						candidates = append(candidates, child)
+					}
+				}
+				matched, err := filterXMLPredicates(candidates, step.predicates)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, matched...)
+			}
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+func xmlDescendantsOrSelf(v starlark.Value, out *[]starlark.Value) error {
+	*out = append(*out, v)
+	e, err := xmlElementParts(v)
+	if err != nil {
+		return err
+	}
+	for _, child := range e.children {
+		if err := xmlDescendantsOrSelf(child, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterXMLPredicates(nodes []starlark.Value, predicates []xmlPredicate) ([]starlark.Value, error) {
+	for _, p := range predicates {
+		if p.position > 0 {
+			if p.position > len(nodes) {
+				return nil, nil
+			}
+			nodes = nodes[p.position-1 : p.position]
+			continue
+		}
+		var kept []starlark.Value
+		for _, n := range nodes {
+			e, err := xmlElementParts(n)
+			if err != nil {
+				return nil, err
+			}
+			ok, err := p.matches(e)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				kept = append(kept, n)
+			}
+		}
+		nodes = kept
+	}
+	return nodes, nil
+}
+
+func (p xmlPredicate) matches(e *xmlParts) (bool, error) {
+	if p.attr {
+		for _, a := range e.attrs {
+			if a.key == p.name && (p.value == nil || a.value == *p.value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	for _, child := range e.children {
+		c, err := xmlElementParts(child)
+		if err != nil {
+			return false, err
+		}
+		if c.tag == p.name && (p.value == nil || c.text == *p.value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func parseXMLPath(path string) ([]xmlStep, error) {
+	var steps []xmlStep
+	descendant := false
+	rest := strings.TrimPrefix(path, "/")
+	if strings.HasPrefix(rest, "/") {
+		descendant = true
+		rest = rest[1:]
+	}
+	for {
+		// Split off the next step, ignoring slashes inside predicates.
+		end, depth := len(rest), 0
+		for i := 0; i < len(rest); i++ {
+			switch rest[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+			case '/':
+				if depth == 0 && end == len(rest) {
+					end = i
+				}
+			}
+		}
+		step, err := parseXMLStep(rest[:end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %s", path, err)
+		}
+		step.descendant = descendant
+		steps = append(steps, step)
+		if end == len(rest) {
+			return steps, nil
+		}
+		rest = rest[end+1:]
+		descendant = false
+		if strings.HasPrefix(rest, "/") {
+			descendant = true
+			rest = rest[1:]
+		}
+	}
+}
+
+func parseXMLStep(s string) (xmlStep, error) {
+	name := s
+	var preds []xmlPredicate
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		name = s[:i]
+		rest := s[i:]
+		for rest != "" {
+			if rest[0] != '[' {
+				return xmlStep{}, fmt.Errorf("unexpected %q", rest)
+			}
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return xmlStep{}, fmt.Errorf("unterminated predicate")
+			}
+			p, err := parseXMLPredicate(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return xmlStep{}, err
+			}
+			preds = append(preds, p)
+			rest = rest[end+1:]
+		}
+	}
+	if name == "" {
+		return xmlStep{}, fmt.Errorf("empty step")
+	}
+	if (strings.HasPrefix(name, "@") || name == "text()") && len(preds) > 0 {
+		return xmlStep{}, fmt.Errorf("%s cannot have predicates", name)
+	}
+	return xmlStep{name: name, predicates: preds}, nil
+}
+
+func parseXMLPredicate(s string) (xmlPredicate, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return xmlPredicate{}, fmt.Errorf("positions start at 1")
+		}
+		return xmlPredicate{position: n}, nil
+	}
+	var p xmlPredicate
+	name := s
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		name = strings.TrimSpace(s[:i])
+		v := strings.TrimSpace(s[i+1:])
+		if len(v) < 2 || (v[0] != '\'' && v[0] != '"') || v[len(v)-1] != v[0] {
+			return xmlPredicate{}, fmt.Errorf("predicate value %s must be quoted", v)
+		}
+		v = v[1 : len(v)-1]
+		p.value = &v
+	}
+	if strings.HasPrefix(name, "@") {
+		p.attr = true
+		name = name[1:]
+	}
+	if name == "" {
+		return xmlPredicate{}, fmt.Errorf("invalid predicate [%s]", s)
+	}
+	p.name = name
+	return p, nil
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccXmlModule_parse(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval(
+						"result = xml.parse(v)",
+						{ v = "<?xml version=\"1.0\"?>\n<settings>\n  <!-- comment -->\n  <add key=\"env\" value=\"prod &amp; dr\"/>\n  <name>web</name>\n</settings>" }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("test", map[string]interface{}{
+						"tag":   "settings",
+						"attrs": map[string]interface{}{},
+						"text":  "",
+						"children": []interface{}{
+							map[string]interface{}{
+								"tag":      "add",
+								"attrs":    map[string]interface{}{"key": "env", "value": "prod & dr"},
+								"text":     "",
+								"children": []interface{}{},
+							},
+							map[string]interface{}{
+								"tag":      "name",
+								"attrs":    map[string]interface{}{},
+								"text":     "web",
+								"children": []interface{}{},
+							},
+						},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccXmlModule_encode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval(
+						<<-EOT
+						result = xml.encode({
+						  "tag": "settings",
+						  "children": [
+						    {"tag": "add", "attrs": {"value": "a & b", "key": "env"}},
+						    {"tag": "name", "text": "web"},
+						  ],
+						})
+						EOT
+						,
+						{}
+					)
+				}
+				output "no_declaration" {
+					value = provider::starlark::eval(
+						"result = xml.encode({'tag': 'a', 'text': '1 < 2'}, declaration = False)",
+						{}
+					)
+				}
+				output "numbers" {
+					value = provider::starlark::eval(
+						"result = xml.encode({'tag': 'listener', 'attrs': {'port': port, 'weight': 0.5}}, declaration = False)",
+						{ port = 8080 }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("test", "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<settings>\n  <add key=\"env\" value=\"a &amp; b\"/>\n  <name>web</name>\n</settings>\n"),
+					resource.TestCheckOutput("no_declaration", "<a>1 &lt; 2</a>\n"),
+					resource.TestCheckOutput("numbers", "<listener port=\"8080\" weight=\"0.5\"/>\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccXmlModule_find(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					config = <<-EOT
+					<configuration>
+					  <appSettings>
+					    <add key="env" value="prod"/>
+					    <add key="region" value="westeurope"/>
+					  </appSettings>
+					  <system.web><compilation debug="false"/></system.web>
+					</configuration>
+					EOT
+				}
+				output "attribute" {
+					value = provider::starlark::eval(
+						"result = xml.find(xml.parse(v), \"appSettings/add[@key='region']/@value\")",
+						{ v = local.config }
+					)
+				}
+				output "descendants" {
+					value = provider::starlark::eval(
+						"result = xml.findall(xml.parse(v), '//add/@key')",
+						{ v = local.config }
+					)
+				}
+				output "absolute" {
+					value = provider::starlark::eval(
+						"result = xml.find(xml.parse(v), '/configuration/system.web/compilation')['attrs']",
+						{ v = local.config }
+					)
+				}
+				output "missing" {
+					value = provider::starlark::eval(
+						"result = xml.find(xml.parse(v), 'appSettings/add[3]')",
+						{ v = local.config }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("attribute", "westeurope"),
+					NewTestCheckOutput("descendants", []interface{}{"env", "region"}),
+					NewTestCheckOutput("absolute", map[string]interface{}{"debug": "false"}),
+					NewTestCheckOutput("missing", nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccXmlModule_invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::starlark::eval("result = xml.parse('<a><b></a>')", {})
+				}
+				`,
+				ExpectError: regexp.MustCompile(`unexpected end element`),
+			},
+		},
+	})
+}
@@ -0,0 +1,367 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlModule implements the "yaml" module.
+//
+// Values are mapped the same way as the json module: mappings become dicts,
+// sequences become lists, and scalars become None, bool, int, float or string.
+var yamlModule = &starlarkstruct.Module{
+	Name: "yaml",
+	Members: starlark.StringDict{
+		"decode":     starlark.NewBuiltin("yaml.decode", yamlDecode),
+		"decode_all": starlark.NewBuiltin("yaml.decode_all", yamlDecodeAll),
+		"encode":     starlark.NewBuiltin("yaml.encode", yamlEncode),
+		"encode_all": starlark.NewBuiltin("yaml.encode_all", yamlEncodeAll),
+	},
+}
+
+// yaml11Implicit matches plain scalars that a YAML 1.1 parser, such as the ones in Kubernetes and
+// cloud-init, resolves to a bool, null, int or float, including forms like "on", "~", "0755" and
+// "1:30" that YAML 1.2 reads as strings. Strings like these are always quoted.
+var yaml11Implicit = regexp.MustCompile(`^(?:` +
+	`y|Y|yes|Yes|YES|n|N|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF|` +
+	`~|null|Null|NULL|` +
+	`[-+]?0b[01_]+|[-+]?0[0-7_]+|[-+]?(?:0|[1-9][0-9_]*)|[-+]?0x[0-9a-fA-F_]+|[-+]?[1-9][0-9_]*(?::[0-5]?[0-9])+|` +
+	`[-+]?(?:[0-9][0-9_]*)?\.[0-9.]*(?:[eE][-+]?[0-9]+)?|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+\.[0-9_]*|` +
+	`[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN)` +
+	`)$`)
+
+func yamlDecode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+	docs, err := decodeYAMLDocuments(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	switch len(docs) {
+	case 0:
+		return starlark.None, nil
+	case 1:
+		return docs[0], nil
+	default:
+		return nil, fmt.Errorf("%s: input contains %d documents, use yaml.decode_all", b.Name(), len(docs))
+	}
+}
+
+func yamlDecodeAll(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var s string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "s", &s); err != nil {
+		return nil, err
+	}
+	docs, err := decodeYAMLDocuments(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.NewList(docs), nil
+}
+
+func yamlEncode(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	indent := 2
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "value", &value, "indent?", &indent); err != nil {
+		return nil, err
+	}
+	out, err := encodeYAMLDocuments([]starlark.Value{value}, indent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(out), nil
+}
+
+func yamlEncodeAll(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var values starlark.Iterable
+	indent := 2
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "values", &values, "indent?", &indent); err != nil {
+		return nil, err
+	}
+	var docs []starlark.Value
+	iter := values.Iterate()
+	defer iter.Done()
+	var elem starlark.Value
+	for iter.Next(&elem) {
+		docs = append(docs, elem)
+	}
+	out, err := encodeYAMLDocuments(docs, indent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	return starlark.String(out), nil
+}
+
+// decodeYAMLDocuments decodes every document in a YAML stream.
+func decodeYAMLDocuments(s string) ([]starlark.Value, error) {
+	dec := yaml.NewDecoder(strings.NewReader(s))
+	var docs []starlark.Value
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		val, err := yamlNodeToStarlark(&node)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, val)
+	}
+}
+
+func yamlNodeToStarlark(node *yaml.Node) (starlark.Value, error) {
+	if node.Anchor != "" {
+		return nil, fmt.Errorf("line %d: anchors are not supported (&%s)", node.Line, node.Anchor)
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return starlark.None, nil
+		}
+		return yamlNodeToStarlark(node.Content[0])
+
+	case yaml.AliasNode:
+		return nil, fmt.Errorf("line %d: aliases are not supported (*%s)", node.Line, node.Value)
+
+	case yaml.SequenceNode:
+		if tag := node.ShortTag(); tag != "!!seq" {
+			return nil, fmt.Errorf("line %d: unsupported tag %s", node.Line, tag)
+		}
+		elems := make([]starlark.Value, 0, len(node.Content))
+		for _, child := range node.Content {
+			val, err := yamlNodeToStarlark(child)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, val)
+		}
+		return starlark.NewList(elems), nil
+
+	case yaml.MappingNode:
+		if tag := node.ShortTag(); tag != "!!map" {
+			return nil, fmt.Errorf("line %d: unsupported tag %s", node.Line, tag)
+		}
+		dict := starlark.NewDict(len(node.Content) / 2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			if keyNode.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: mapping keys must be scalars", keyNode.Line)
+			}
+			if keyNode.ShortTag() == "!!merge" {
+				return nil, fmt.Errorf("line %d: merge keys are not supported", keyNode.Line)
+			}
+			val, err := yamlNodeToStarlark(valueNode)
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(keyNode.Value), val); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+
+	case yaml.ScalarNode:
+		return yamlScalarToStarlark(node)
+
+	default:
+		return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+	}
+}
+
+func yamlScalarToStarlark(node *yaml.Node) (starlark.Value, error) {
+	switch tag := node.ShortTag(); tag {
+	case "!!null":
+		return starlark.None, nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, err
+		}
+		return starlark.Bool(b), nil
+	case "!!int":
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return nil, err
+		}
+		i, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid integer %q", node.Line, node.Value)
+		}
+		return starlark.MakeBigInt(i), nil
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return nil, err
+		}
+		return starlark.Float(f), nil
+	case "!!str", "!!timestamp":
+		// Timestamps are kept in their textual form, as with JSON strings.
+		return starlark.String(node.Value), nil
+	case "!!binary":
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(node.Value), ""))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid !!binary value: %s", node.Line, err)
+		}
+		return starlark.Bytes(data), nil
+	default:
+		return nil, fmt.Errorf("line %d: unsupported tag %s", node.Line, tag)
+	}
+}
+
+// encodeYAMLDocuments encodes values as a YAML stream, one document per value.
+func encodeYAMLDocuments(values []starlark.Value, indent int) (string, error) {
+	if indent < 1 {
+		return "", fmt.Errorf("indent must be positive, got %d", indent)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	for i, v := range values {
+		node, err := starlarkToYAMLNode(v, nil)
+		if err != nil {
+			if len(values) > 1 {
+				return "", fmt.Errorf("document %d: %s", i, err)
+			}
+			return "", err
+		}
+		if err := enc.Encode(node); err != nil {
+			return "", err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// starlarkToYAMLNode converts a Starlark value to a YAML node. Mapping keys are sorted so the output is stable.
+func starlarkToYAMLNode(x starlark.Value, path []starlark.Value) (*yaml.Node, error) {
+	switch x.(type) {
+	case *starlark.Dict, *starlark.List:
+		for _, seen := range path {
+			if seen == x {
+				return nil, fmt.Errorf("cycle in YAML structure")
+			}
+		}
+	}
+
+	switch x := x.(type) {
+	case starlark.NoneType:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+
+	case starlark.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(bool(x))}, nil
+
+	case starlark.Int:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: x.String()}, nil
+
+	case starlark.Float:
+		f := float64(x)
+		var s string
+		switch {
+		case math.IsNaN(f):
+			s = ".nan"
+		case math.IsInf(f, 1):
+			s = ".inf"
+		case math.IsInf(f, -1):
+			s = "-.inf"
+		default:
+			// Float.String always contains a decimal point, so the value reads back as a float.
+			s = x.String()
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: s}, nil
+
+	case starlark.String:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: string(x)}
+		switch {
+		case strings.Contains(string(x), "\n"):
+			node.Style = yaml.LiteralStyle
+		case yaml11Implicit.MatchString(string(x)):
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		return node, nil
+
+	case starlark.Bytes:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!binary", Value: base64.StdEncoding.EncodeToString([]byte(x))}, nil
+
+	case starlark.IterableMapping:
+		// e.g. dict (must have string keys)
+		items := x.Items()
+		for _, item := range items {
+			if _, ok := item[0].(starlark.String); !ok {
+				return nil, fmt.Errorf("%s has %s key, want string", x.Type(), item[0].Type())
+			}
+		}
+		sort.Slice(items, func(i, j int) bool {
+			return items[i][0].(starlark.String) < items[j][0].(starlark.String)
+		})
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, item := range items {
+			k, _ := starlark.AsString(item[0])
+			child, err := starlarkToYAMLNode(item[1], append(path, x))
+			if err != nil {
+				return nil, fmt.Errorf("in %s key %q: %v", x.Type(), k, err)
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, child)
+		}
+		return node, nil
+
+	case starlark.Iterable:
+		// e.g. tuple, list
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		iter := x.Iterate()
+		defer iter.Done()
+		var elem starlark.Value
+		for i := 0; iter.Next(&elem); i++ {
+			child, err := starlarkToYAMLNode(elem, append(path, x))
+			if err != nil {
+				return nil, fmt.Errorf("at %s index %d: %v", x.Type(), i, err)
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+
+	case starlark.HasAttrs:
+		// e.g. struct
+		names := append([]string(nil), x.AttrNames()...)
+		sort.Strings(names)
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, name := range names {
+			v, err := x.Attr(name)
+			if err != nil || v == nil {
+				return nil, fmt.Errorf("cannot access attribute %s.%s", x.Type(), name)
+			}
+			child, err := starlarkToYAMLNode(v, append(path, x))
+			if err != nil {
+				return nil, fmt.Errorf("in field .%s: %v", name, err)
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, child)
+		}
+		return node, nil
+
+	default:
+		return nil, fmt.Errorf("cannot encode %s as YAML", x.Type())
+	}
+}
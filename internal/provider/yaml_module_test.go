@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccYamlModule_decode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "single" {
+					value = provider::starlark::eval(
+						"result = yaml.decode(v)",
+						{ v = "name: web\nreplicas: 3\nports: [80, 443]\n" }
+					)
+				}
+				output "multi" {
+					value = provider::starlark::eval(
+						"result = [doc['kind'] for doc in yaml.decode_all(v)]",
+						{ v = "kind: Service\n---\nkind: Deployment\n" }
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					NewTestCheckOutput("single", map[string]interface{}{
+						"name":     "web",
+						"replicas": json.Number("3"),
+						"ports":    []interface{}{json.Number("80"), json.Number("443")},
+					}),
+					NewTestCheckOutput("multi", []interface{}{"Service", "Deployment"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccYamlModule_encode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "sorted" {
+					value = provider::starlark::eval(
+						"result = yaml.encode({'b': [1, 2], 'a': 'true'})",
+						{}
+					)
+				}
+				output "indent" {
+					value = provider::starlark::eval(
+						"result = yaml.encode({'a': {'b': 1}}, indent = 4)",
+						{}
+					)
+				}
+				output "yaml11" {
+					value = provider::starlark::eval(
+						"result = yaml.encode({'s': 'yes', 'o': 'on', 'n': '~', 'mode': '0755', 'time': '1:30', 'name': 'web'})",
+						{}
+					)
+				}
+				output "stream" {
+					value = provider::starlark::eval(
+						"result = yaml.encode_all([{'a': 1}, {'b': 2}])",
+						{}
+					)
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("sorted", "a: \"true\"\nb:\n  - 1\n  - 2\n"),
+					resource.TestCheckOutput("indent", "a:\n    b: 1\n"),
+					resource.TestCheckOutput("yaml11", "mode: \"0755\"\nn: \"~\"\nname: web\no: \"on\"\ns: \"yes\"\ntime: \"1:30\"\n"),
+					resource.TestCheckOutput("stream", "a: 1\n---\nb: 2\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccYamlModule_unsupported(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "anchor" {
+					value = provider::starlark::eval("result = yaml.decode(v)", { v = "a: &x 1\nb: *x\n" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`anchors are not supported`),
+			},
+			{
+				Config: `
+				output "tag" {
+					value = provider::starlark::eval("result = yaml.decode(v)", { v = "a: !Ref foo\n" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`unsupported tag !Ref`),
+			},
+		},
+	})
+}